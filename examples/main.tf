@@ -13,6 +13,9 @@ provider "julieops" {
   sasl_password = "kafka"
   sasl_mechanism = "plain"
   kafka_connects = "http://localhost:18083"
+  schema_registry {
+    url = "http://localhost:8081"
+  }
 }
 
 
@@ -34,6 +37,25 @@ resource "julieops_kafka_consumer_acl" "consumer" {
   }
 }
 
+resource "julieops_kafka_consumer_acl" "reader" {
+  principal = "User:reader"
+  topics = [ "context.project.orders" ]
+  groups {
+    name = "reader."
+    pattern_type = "Prefixed"
+  }
+}
+
+resource "julieops_kafka_producer_acl" "producer" {
+  project = "context.project"
+  principal = "User:producer"
+  transactional_id = "context.project.producer"
+  idempotence = true
+  metadata = {
+    "foo" = "bar"
+  }
+}
+
 resource "julieops_kafka_streams_acl" "kstreams_v1" {
   project = "context.project"
   principal = "User:streams_v1"
@@ -89,4 +111,43 @@ output "all_topics" {
 
 output "custom_topic" {
   value = julieops_kafka_topic.custom_topic
-}
\ No newline at end of file
+}
+data "julieops_kafka_topic" "custom_topic" {
+  name = julieops_kafka_topic.custom_topic.name
+}
+
+output "custom_topic_under_replicated_partitions" {
+  value = data.julieops_kafka_topic.custom_topic.under_replicated_partitions
+}
+
+resource "julieops_kafka_acl" "describe_cluster" {
+  resource_type = "Cluster"
+  resource_name = "kafka-cluster"
+  principal     = "User:monitoring"
+  operation     = "Describe"
+}
+
+resource "julieops_schema" "foo_value" {
+  topic = julieops_kafka_topic.custom_topic.name
+  schema_type = "AVRO"
+  schema = jsonencode({
+    type = "record"
+    name = "Foo"
+    fields = [
+      { name = "id", type = "string" }
+    ]
+  })
+}
+
+resource "julieops_schema_compatibility" "foo_value" {
+  subject = julieops_schema.foo_value.subject
+  compatibility = "FULL_TRANSITIVE"
+}
+
+data "julieops_schema" "foo_value" {
+  subject = julieops_schema.foo_value.subject
+}
+
+output "foo_value_schema_id" {
+  value = data.julieops_schema.foo_value.schema_id
+}
@@ -2,7 +2,6 @@ package client
 
 import (
 	"github.com/Shopify/sarama"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 type KafkaAclsBuilder struct {
@@ -18,54 +17,140 @@ type AclResourceInfo struct {
 	Acl      sarama.Acl
 }
 
-type Convert func(d *schema.ResourceData) interface{}
+// ResourceGetter gives access to the attributes of a resource, as *schema.ResourceData does.
+type ResourceGetter interface {
+	Get(key string) interface{}
+}
+
+// AclDenyRule describes the operations explicitly denied on a set of topics.
+type AclDenyRule struct {
+	Operations    []string
+	Topics        []string
+	TopicPrefixes []string
+}
+
+// AclRestrictions narrows the bindings of a bundle to a set of hosts and adds explicit DENY bindings.
+type AclRestrictions struct {
+	Hosts []string
+	Deny  []AclDenyRule
+}
+
+// Apply replicates every binding once per host, all hosts if none is given, and appends the DENY bindings.
+func (r AclRestrictions) Apply(principal string, resources AclResources) (AclResources, error) {
+	hosts := r.Hosts
+	if len(hosts) == 0 {
+		hosts = []string{"*"}
+	}
+
+	resourceInfos := make([]AclResourceInfo, 0, len(resources.Resources)*len(hosts))
+	for _, resource := range resources.Resources {
+		for _, host := range hosts {
+			resource.Acl.Host = host
+			resourceInfos = append(resourceInfos, resource)
+		}
+	}
+
+	for _, rule := range r.Deny {
+		for _, operationName := range rule.Operations {
+			var operation sarama.AclOperation
+			if err := operation.UnmarshalText([]byte(operationName)); err != nil {
+				return AclResources{}, err
+			}
+
+			topicResources, acls := createTopicAcls(rule.Topics, principal, operation)
+			prefixResources, prefixAcls := createTopicAclsWithPattern(rule.TopicPrefixes, principal, operation, sarama.AclPatternPrefixed)
+			topicResources = append(topicResources, prefixResources...)
+			acls = append(acls, prefixAcls...)
+
+			for j, resource := range topicResources {
+				acl := acls[j]
+				acl.PermissionType = sarama.AclPermissionDeny
+				for _, host := range hosts {
+					acl.Host = host
+					resourceInfos = append(resourceInfos, AclResourceInfo{Resource: resource, Acl: acl})
+				}
+			}
+		}
+	}
+
+	return AclResources{Resources: resourceInfos}, nil
+}
+
+type Convert func(d ResourceGetter) interface{}
 
 type AclBuilder func(acl interface{}) (AclResources, error)
 
-func (b KafkaAclsBuilder) BuildAcls(d *schema.ResourceData, fnConvert Convert, fnBuilder AclBuilder) (interface{}, AclResources, error) {
+func (b KafkaAclsBuilder) BuildAcls(d ResourceGetter, fnConvert Convert, fnBuilder AclBuilder) (interface{}, AclResources, error) {
 	acl := fnConvert(d)
 	resources, err := fnBuilder(acl)
 	return acl, resources, err
 }
 
 func (b KafkaAclsBuilder) ConsumerAclsBuilder(aclInterface interface{}) (AclResources, error) {
-	resources := make([]AclResourceInfo, 3)
 	consumerAcl := aclInterface.(ConsumerAcl)
 
-	resource := sarama.Resource{
-		ResourceName:        consumerAcl.Project,
-		ResourceType:        sarama.AclResourceTopic,
-		ResourcePatternType: sarama.AclPatternPrefixed,
+	topics := make([]sarama.Resource, 0)
+	if consumerAcl.Project != "" {
+		topics = append(topics, sarama.Resource{
+			ResourceName:        consumerAcl.Project,
+			ResourceType:        sarama.AclResourceTopic,
+			ResourcePatternType: sarama.AclPatternPrefixed,
+		})
+	}
+	for _, topic := range consumerAcl.Topics {
+		topics = append(topics, sarama.Resource{
+			ResourceName:        topic,
+			ResourceType:        sarama.AclResourceTopic,
+			ResourcePatternType: sarama.AclPatternLiteral,
+		})
+	}
+	for _, prefix := range consumerAcl.TopicPrefixes {
+		topics = append(topics, sarama.Resource{
+			ResourceName:        prefix,
+			ResourceType:        sarama.AclResourceTopic,
+			ResourcePatternType: sarama.AclPatternPrefixed,
+		})
 	}
 
+	resources := make([]AclResourceInfo, 0)
 	operations := []sarama.AclOperation{sarama.AclOperationDescribe, sarama.AclOperationRead}
-	i := 0
-	for _, operation := range operations {
-		acl := sarama.Acl{
-			Principal:      consumerAcl.Principal,
-			Host:           "*",
-			Operation:      operation,
-			PermissionType: sarama.AclPermissionAllow,
+	for _, resource := range topics {
+		for _, operation := range operations {
+			acl := sarama.Acl{
+				Principal:      consumerAcl.Principal,
+				Host:           "*",
+				Operation:      operation,
+				PermissionType: sarama.AclPermissionAllow,
+			}
+			resources = append(resources, AclResourceInfo{Resource: resource, Acl: acl})
 		}
-		resources[i] = AclResourceInfo{Resource: resource, Acl: acl}
-		i = i + 1
 	}
 
-	resource = sarama.Resource{
-		ResourceName:        consumerAcl.Group,
-		ResourceType:        sarama.AclResourceGroup,
-		ResourcePatternType: sarama.AclPatternLiteral,
+	groups := consumerAcl.Groups
+	if len(groups) == 0 {
+		groups = []ConsumerGroup{{Name: consumerAcl.Group, PatternType: "Literal"}}
 	}
+	for _, group := range groups {
+		var patternType sarama.AclResourcePatternType
+		if err := patternType.UnmarshalText([]byte(group.PatternType)); err != nil {
+			return AclResources{}, err
+		}
 
-	acl := sarama.Acl{
-		Principal:      consumerAcl.Principal,
-		Host:           "*",
-		Operation:      sarama.AclOperationRead,
-		PermissionType: sarama.AclPermissionAllow,
+		resource := sarama.Resource{
+			ResourceName:        group.Name,
+			ResourceType:        sarama.AclResourceGroup,
+			ResourcePatternType: patternType,
+		}
+		acl := sarama.Acl{
+			Principal:      consumerAcl.Principal,
+			Host:           "*",
+			Operation:      sarama.AclOperationRead,
+			PermissionType: sarama.AclPermissionAllow,
+		}
+		resources = append(resources, AclResourceInfo{Resource: resource, Acl: acl})
 	}
-	resources[2] = AclResourceInfo{Resource: resource, Acl: acl}
 
-	return AclResources{Resources: resources}, nil
+	return consumerAcl.AclRestrictions.Apply(consumerAcl.Principal, AclResources{Resources: resources})
 }
 
 func (b KafkaAclsBuilder) KafkaStreamsAclsBuilder(aclInterface interface{}) (AclResources, error) {
@@ -85,11 +170,72 @@ func (b KafkaAclsBuilder) KafkaStreamsAclsBuilder(aclInterface interface{}) (Acl
 		i = i + 1
 	}
 
-	resource, acl := createKStreamAcl(kStreamsAcl.Project, kStreamsAcl.Principal, sarama.AclResourceTopic, sarama.AclOperationAll)
+	resource, acl := createKStreamAcl(kStreamsAcl.AppId(), kStreamsAcl.Principal, sarama.AclResourceTopic, sarama.AclOperationAll)
 	resourceInfos[i] = AclResourceInfo{Resource: resource, Acl: acl}
-	resource, acl = createKStreamAcl(kStreamsAcl.Project, kStreamsAcl.Principal, sarama.AclResourceGroup, sarama.AclOperationRead)
+	resource, acl = createKStreamAcl(kStreamsAcl.AppId(), kStreamsAcl.Principal, sarama.AclResourceGroup, sarama.AclOperationRead)
 	resourceInfos[i+1] = AclResourceInfo{Resource: resource, Acl: acl}
-	return AclResources{Resources: resourceInfos}, nil
+
+	// the project topics stay granted when the application id sets a different prefix
+	if kStreamsAcl.AppId() != kStreamsAcl.Project {
+		resource, acl = createKStreamAcl(kStreamsAcl.Project, kStreamsAcl.Principal, sarama.AclResourceTopic, sarama.AclOperationAll)
+		resourceInfos = append(resourceInfos, AclResourceInfo{Resource: resource, Acl: acl})
+	}
+
+	resources, acls = createTopicAclsWithPattern(kStreamsAcl.ReadTopicPrefixes, kStreamsAcl.Principal, sarama.AclOperationRead, sarama.AclPatternPrefixed)
+	for j, resource := range resources {
+		resourceInfos = append(resourceInfos, AclResourceInfo{Resource: resource, Acl: acls[j]})
+	}
+
+	resources, acls = createTopicAclsWithPattern(kStreamsAcl.WriteTopicPrefixes, kStreamsAcl.Principal, sarama.AclOperationWrite, sarama.AclPatternPrefixed)
+	for j, resource := range resources {
+		resourceInfos = append(resourceInfos, AclResourceInfo{Resource: resource, Acl: acls[j]})
+	}
+
+	if kStreamsAcl.ExactlyOnce {
+		resourceInfos = append(resourceInfos, kafkaStreamsExactlyOnceAcls(kStreamsAcl)...)
+	}
+
+	return kStreamsAcl.AclRestrictions.Apply(kStreamsAcl.Principal, AclResources{Resources: resourceInfos})
+}
+
+// kafkaStreamsExactlyOnceAcls builds the bindings required by processing.guarantee=exactly_once_v2, the
+// transactional ids of the application are prefixed with its application.id.
+func kafkaStreamsExactlyOnceAcls(kStreamsAcl KafkaStreamsAcl) []AclResourceInfo {
+	resourceInfos := make([]AclResourceInfo, 0)
+
+	for _, operation := range []sarama.AclOperation{sarama.AclOperationDescribe, sarama.AclOperationWrite} {
+		resource, acl := createKStreamAcl(kStreamsAcl.AppId(), kStreamsAcl.Principal, sarama.AclResourceTransactionalID, operation)
+		resourceInfos = append(resourceInfos, AclResourceInfo{Resource: resource, Acl: acl})
+	}
+
+	resource := sarama.Resource{
+		ResourceName:        "kafka-cluster",
+		ResourceType:        sarama.AclResourceCluster,
+		ResourcePatternType: sarama.AclPatternLiteral,
+	}
+
+	acl := sarama.Acl{
+		Principal:      kStreamsAcl.Principal,
+		Host:           "*",
+		Operation:      sarama.AclOperationIdempotentWrite,
+		PermissionType: sarama.AclPermissionAllow,
+	}
+	resourceInfos = append(resourceInfos, AclResourceInfo{Resource: resource, Acl: acl})
+
+	// the source and sink topics configuration is validated by the application before processing
+	topics := append(append([]string{}, kStreamsAcl.ReadTopics...), kStreamsAcl.WriteTopics...)
+	resources, acls := createTopicAcls(topics, kStreamsAcl.Principal, sarama.AclOperationDescribeConfigs)
+	for j, resource := range resources {
+		resourceInfos = append(resourceInfos, AclResourceInfo{Resource: resource, Acl: acls[j]})
+	}
+
+	prefixes := append(append([]string{}, kStreamsAcl.ReadTopicPrefixes...), kStreamsAcl.WriteTopicPrefixes...)
+	resources, acls = createTopicAclsWithPattern(prefixes, kStreamsAcl.Principal, sarama.AclOperationDescribeConfigs, sarama.AclPatternPrefixed)
+	for j, resource := range resources {
+		resourceInfos = append(resourceInfos, AclResourceInfo{Resource: resource, Acl: acls[j]})
+	}
+
+	return resourceInfos
 }
 
 func (b KafkaAclsBuilder) KafkaConnectAclsBuilder(aclInterface interface{}) (AclResources, error) {
@@ -154,141 +300,143 @@ func (b KafkaAclsBuilder) KafkaConnectAclsBuilder(aclInterface interface{}) (Acl
 	resourceInfos[i] = AclResourceInfo{Resource: resource, Acl: acl}
 	i = i + 1
 
-	return AclResources{Resources: resourceInfos}, nil
+	return kafkaConnectAcl.AclRestrictions.Apply(kafkaConnectAcl.Principal, AclResources{Resources: resourceInfos})
 }
 
-func GetNumberOfResourcesForKafkaConnect(kafkaConnecAcl KafkaConnectAcl) int {
-	resourcesCount := len(kafkaConnecAcl.ReadTopics) + len(kafkaConnecAcl.WriteTopics) + 6 + 1
-	if kafkaConnecAcl.EnableTopicCreate {
-		resourcesCount = resourcesCount + 1
-	}
-
-	return resourcesCount
-}
+func (b KafkaAclsBuilder) ProducerAclsBuilder(aclInterface interface{}) (AclResources, error) {
+	producerAcl := aclInterface.(ProducerAcl)
+	resourceInfos := make([]AclResourceInfo, 0)
 
-func (b KafkaAclsBuilder) ConsumerAclsParser(d *schema.ResourceData,
-	aclInterface interface{},
-	aclEntity sarama.ResourceAcls) error {
+	operations := []sarama.AclOperation{sarama.AclOperationDescribe, sarama.AclOperationWrite}
 
-	consumerAcl := aclInterface.(ConsumerAcl)
-
-	for _, acl := range aclEntity.Acls {
-		if acl.Principal == consumerAcl.Principal {
-			d.Set("principal", acl.Principal)
-			if b.Client.IsAGroupAcl(aclEntity) {
-				d.Set("group", aclEntity.ResourceName)
+	if producerAcl.Project != "" {
+		for _, operation := range operations {
+			resources, acls := createTopicAclsWithPattern([]string{producerAcl.Project}, producerAcl.Principal, operation, sarama.AclPatternPrefixed)
+			for j, resource := range resources {
+				resourceInfos = append(resourceInfos, AclResourceInfo{Resource: resource, Acl: acls[j]})
 			}
-			if b.Client.IsATopicAcl(aclEntity) {
-				d.Set("project", aclEntity.ResourceName)
-			}
-			d.Set("metadata", consumerAcl.Metadata)
 		}
 	}
 
-	return nil
-}
-
-func (b KafkaAclsBuilder) KafkaStreamsAclsParser(d *schema.ResourceData,
-	aclInterface interface{},
-	aclEntity sarama.ResourceAcls) error {
-
-	kStreamAcl := aclInterface.(KafkaStreamsAcl)
-	readTopics := make([]string, 0)
-	writeTopics := make([]string, 0)
-
-	for _, acl := range aclEntity.Acls {
-
-		if acl.Principal == kStreamAcl.Principal {
-			d.Set("principal", acl.Principal)
-			if b.Client.IsAGroupAcl(aclEntity) {
-				d.Set("group", aclEntity.ResourceName)
-			}
-			if b.Client.IsATopicAcl(aclEntity) {
-				if aclEntity.ResourcePatternType == sarama.AclPatternPrefixed {
-					d.Set("project", aclEntity.ResourceName)
-				} else {
-					if acl.Operation == sarama.AclOperationRead {
-						readTopics = append(readTopics, aclEntity.ResourceName)
-					} else {
-						writeTopics = append(writeTopics, aclEntity.ResourceName)
-					}
+	for _, operation := range operations {
+		resources, acls := createTopicAcls(producerAcl.Topics, producerAcl.Principal, operation)
+		for j, resource := range resources {
+			resourceInfos = append(resourceInfos, AclResourceInfo{Resource: resource, Acl: acls[j]})
+		}
+	}
 
-				}
+	if producerAcl.TransactionalId != "" {
+		resource := sarama.Resource{
+			ResourceName:        producerAcl.TransactionalId,
+			ResourceType:        sarama.AclResourceTransactionalID,
+			ResourcePatternType: sarama.AclPatternLiteral,
+		}
+		for _, operation := range operations {
+			acl := sarama.Acl{
+				Principal:      producerAcl.Principal,
+				Host:           "*",
+				Operation:      operation,
+				PermissionType: sarama.AclPermissionAllow,
 			}
-			d.Set("read_topics", readTopics)
-			d.Set("write_topics", writeTopics)
-			d.Set("metadata", kStreamAcl.Metadata)
+			resourceInfos = append(resourceInfos, AclResourceInfo{Resource: resource, Acl: acl})
 		}
 	}
 
-	return nil
-}
+	if producerAcl.Idempotence {
+		resource := sarama.Resource{
+			ResourceName:        "kafka-cluster",
+			ResourceType:        sarama.AclResourceCluster,
+			ResourcePatternType: sarama.AclPatternLiteral,
+		}
 
-func (b KafkaAclsBuilder) KafkaConnectAclsParser(d *schema.ResourceData,
-	aclInterface interface{},
-	aclEntity sarama.ResourceAcls) error {
+		acl := sarama.Acl{
+			Principal:      producerAcl.Principal,
+			Host:           "*",
+			Operation:      sarama.AclOperationIdempotentWrite,
+			PermissionType: sarama.AclPermissionAllow,
+		}
+		resourceInfos = append(resourceInfos, AclResourceInfo{Resource: resource, Acl: acl})
+	}
 
-	kafkaConnectAcl := aclInterface.(KafkaConnectAcl)
-	readTopics := make([]string, 0)
-	writeTopics := make([]string, 0)
+	return producerAcl.AclRestrictions.Apply(producerAcl.Principal, AclResources{Resources: resourceInfos})
+}
 
-	controlTopics := make(map[string]bool)
-	controlTopics[kafkaConnectAcl.StatusTopic] = true
-	controlTopics[kafkaConnectAcl.OffsetTopic] = true
-	controlTopics[kafkaConnectAcl.ConfigsTopic] = true
+func (b KafkaAclsBuilder) KafkaAclBuilder(aclInterface interface{}) (AclResources, error) {
+	kafkaAcl := aclInterface.(KafkaAcl)
 
-	for _, acl := range aclEntity.Acls {
+	var resourceType sarama.AclResourceType
+	if err := resourceType.UnmarshalText([]byte(kafkaAcl.ResourceType)); err != nil {
+		return AclResources{}, err
+	}
+	var patternType sarama.AclResourcePatternType
+	if err := patternType.UnmarshalText([]byte(kafkaAcl.PatternType)); err != nil {
+		return AclResources{}, err
+	}
+	var operation sarama.AclOperation
+	if err := operation.UnmarshalText([]byte(kafkaAcl.Operation)); err != nil {
+		return AclResources{}, err
+	}
+	var permission sarama.AclPermissionType
+	if err := permission.UnmarshalText([]byte(kafkaAcl.Permission)); err != nil {
+		return AclResources{}, err
+	}
 
-		if acl.Principal == kafkaConnectAcl.Principal {
-			d.Set("principal", acl.Principal)
-			if b.Client.IsAGroupAcl(aclEntity) {
-				d.Set("group", aclEntity.ResourceName)
-			}
+	resource := sarama.Resource{
+		ResourceName:        kafkaAcl.ResourceName,
+		ResourceType:        resourceType,
+		ResourcePatternType: patternType,
+	}
 
-			if b.Client.IsATopicAcl(aclEntity) {
-
-				if controlTopics[aclEntity.ResourceName] {
-					topicNameKey := ""
-					if aclEntity.ResourceName == kafkaConnectAcl.OffsetTopic {
-						topicNameKey = "offset_topic"
-					} else if aclEntity.ResourceName == kafkaConnectAcl.StatusTopic {
-						topicNameKey = "status_topic"
-					} else {
-						topicNameKey = "configs_topic"
-					}
-					d.Set(topicNameKey, aclEntity.ResourceName)
-				} else {
-					if acl.Operation == sarama.AclOperationRead {
-						readTopics = append(readTopics, aclEntity.ResourceName)
-					} else {
-						writeTopics = append(writeTopics, aclEntity.ResourceName)
-					}
-				}
-			}
-			if b.Client.IsAClusterAcl(aclEntity) {
-				d.Set("enable_topic_create", true)
-			}
-		}
-		d.Set("read_topics", readTopics)
-		d.Set("write_topics", writeTopics)
-		d.Set("metadata", kafkaConnectAcl.Metadata)
+	acl := sarama.Acl{
+		Principal:      kafkaAcl.Principal,
+		Host:           kafkaAcl.Host,
+		Operation:      operation,
+		PermissionType: permission,
 	}
 
-	return nil
+	return AclResources{Resources: []AclResourceInfo{{Resource: resource, Acl: acl}}}, nil
 }
 
-func (b KafkaAclsBuilder) ConsumerAclShouldContinue(entity sarama.ResourceAcls, aclInterface interface{}) bool {
-	consumerAcl := aclInterface.(ConsumerAcl)
-	return entity.ResourceName != consumerAcl.Project
+// Strings returns the human readable representation of every binding, as used in the acl_bindings attribute.
+func (r AclResources) Strings() []string {
+	result := make([]string, len(r.Resources))
+	for i, resource := range r.Resources {
+		result[i] = DescribeAclResource(resource)
+	}
+	return result
 }
 
-func (b KafkaAclsBuilder) KafkaStreamsAclShouldContinue(entity sarama.ResourceAcls, aclInterface interface{}) bool {
-	acl := aclInterface.(KafkaStreamsAcl)
-	return entity.ResourceName != acl.Project
+// DiffAclResources returns the bindings present only in the new set (added) and only in the old set (removed).
+func DiffAclResources(oldResources AclResources, newResources AclResources) (added AclResources, removed AclResources) {
+	oldSet := make(map[AclResourceInfo]bool, len(oldResources.Resources))
+	for _, resource := range oldResources.Resources {
+		oldSet[resource] = true
+	}
+	newSet := make(map[AclResourceInfo]bool, len(newResources.Resources))
+	for _, resource := range newResources.Resources {
+		newSet[resource] = true
+	}
+
+	for _, resource := range newResources.Resources {
+		if !oldSet[resource] {
+			added.Resources = append(added.Resources, resource)
+			oldSet[resource] = true
+		}
+	}
+	for _, resource := range oldResources.Resources {
+		if !newSet[resource] {
+			removed.Resources = append(removed.Resources, resource)
+			newSet[resource] = true
+		}
+	}
+	return added, removed
 }
 
-func (b KafkaAclsBuilder) KafkaConnectAclShouldContinue(entity sarama.ResourceAcls, aclInterface interface{}) bool {
-	aclObject := aclInterface.(KafkaConnectAcl)
-	acl := entity.Acls[0]
-	return acl.Principal != aclObject.Principal
+func GetNumberOfResourcesForKafkaConnect(kafkaConnecAcl KafkaConnectAcl) int {
+	resourcesCount := len(kafkaConnecAcl.ReadTopics) + len(kafkaConnecAcl.WriteTopics) + 6 + 1
+	if kafkaConnecAcl.EnableTopicCreate {
+		resourcesCount = resourcesCount + 1
+	}
+
+	return resourcesCount
 }
@@ -2,17 +2,20 @@ package client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/Shopify/sarama"
 	"log"
+	"sort"
 	"strings"
 	"time"
 )
 
 type KafkaCluster struct {
-	BootstrapServers   []string
-	Config             Config
-	KafkaConnectClient KafkaConnectCluster
+	BootstrapServers     []string
+	Config               Config
+	KafkaConnectClient   KafkaConnectCluster
+	SchemaRegistryClient *SchemaRegistryCluster
 }
 
 type Config struct {
@@ -31,44 +34,143 @@ type Topic struct {
 }
 
 type ConsumerAcl struct {
-	Id        string
-	Project   string
-	Principal string
-	Group     string
-	Metadata  map[string]string
+	AclRestrictions
+	Id            string
+	Project       string
+	Principal     string
+	Group         string
+	Topics        []string
+	TopicPrefixes []string
+	Groups        []ConsumerGroup
+	Metadata      map[string]string
 }
 
-func NewConsumerAcl(project string, principal string, group string, metadata map[string]string) *ConsumerAcl {
+// ConsumerGroup is a consumer group name together with the pattern type used to match it.
+type ConsumerGroup struct {
+	Name        string
+	PatternType string
+}
+
+func NewConsumerAcl(project string, principal string, group string, topics []string, topicPrefixes []string,
+	groups []ConsumerGroup, metadata map[string]string) *ConsumerAcl {
 	return &ConsumerAcl{
-		Id:        fmt.Sprintf("%s#%s#%s", project, principal, group),
-		Project:   project,
-		Principal: principal,
-		Group:     group,
-		Metadata:  metadata,
+		Id:            consumerAclId(project, principal, group, topics, topicPrefixes),
+		Project:       project,
+		Principal:     principal,
+		Group:         group,
+		Topics:        topics,
+		TopicPrefixes: topicPrefixes,
+		Groups:        groups,
+		Metadata:      metadata,
 	}
 }
 
-type KafkaStreamsAcl struct {
-	Id          string
-	Project     string
-	Principal   string
-	ReadTopics  []string
-	WriteTopics []string
-	Metadata    map[string]string
+// consumerAclId identifies a consumer by project#principal#group, followed by #topic1,topic2 with its sorted topics
+// and #prefix1,prefix2 with its sorted topic prefixes when it has any, so consumers of the same principal and group
+// on different topics get distinct ids.
+func consumerAclId(project string, principal string, group string, topics []string, topicPrefixes []string) string {
+	id := fmt.Sprintf("%s#%s#%s", project, principal, group)
+	if len(topics) == 0 && len(topicPrefixes) == 0 {
+		return id
+	}
+	id = id + "#" + sortedList(topics)
+	if len(topicPrefixes) > 0 {
+		id = id + "#" + sortedList(topicPrefixes)
+	}
+	return id
 }
 
-func NewKafkaStreamsAcl(project string, principal string, readTopics []string, writeTopics []string, metadata map[string]string) *KafkaStreamsAcl {
+type KafkaStreamsAcl struct {
+	AclRestrictions
+	Id                 string
+	Project            string
+	Principal          string
+	ApplicationId      string
+	ReadTopics         []string
+	WriteTopics        []string
+	ReadTopicPrefixes  []string
+	WriteTopicPrefixes []string
+	ExactlyOnce        bool
+	Metadata           map[string]string
+}
+
+func NewKafkaStreamsAcl(project string, principal string, applicationId string, readTopics []string, writeTopics []string,
+	readTopicPrefixes []string, writeTopicPrefixes []string, exactlyOnce bool, metadata map[string]string) *KafkaStreamsAcl {
 	return &KafkaStreamsAcl{
-		Id:          fmt.Sprintf("%s#%s", project, principal),
-		Project:     project,
-		Principal:   principal,
-		ReadTopics:  readTopics,
-		WriteTopics: writeTopics,
-		Metadata:    metadata,
+		Id:                 kafkaStreamsAclId(project, principal, applicationId),
+		Project:            project,
+		Principal:          principal,
+		ApplicationId:      applicationId,
+		ReadTopics:         readTopics,
+		WriteTopics:        writeTopics,
+		ReadTopicPrefixes:  readTopicPrefixes,
+		WriteTopicPrefixes: writeTopicPrefixes,
+		ExactlyOnce:        exactlyOnce,
+		Metadata:           metadata,
+	}
+}
+
+// kafkaStreamsAclId identifies a Kafka Streams application by project#principal, followed by #application_id when
+// it has an application id other than the project, so applications of the same project and principal get distinct ids.
+func kafkaStreamsAclId(project string, principal string, applicationId string) string {
+	if applicationId == "" || applicationId == project {
+		return fmt.Sprintf("%s#%s", project, principal)
+	}
+	return fmt.Sprintf("%s#%s#%s", project, principal, applicationId)
+}
+
+// AppId returns the application.id of the Kafka Streams application, the project if none was given. It is
+// used as prefix for the internal topics, the consumer group and the transactional ids.
+func (k KafkaStreamsAcl) AppId() string {
+	if k.ApplicationId != "" {
+		return k.ApplicationId
+	}
+	return k.Project
+}
+
+type ProducerAcl struct {
+	AclRestrictions
+	Id              string
+	Project         string
+	Principal       string
+	Topics          []string
+	TransactionalId string
+	Idempotence     bool
+	Metadata        map[string]string
+}
+
+func NewProducerAcl(project string, principal string, topics []string, transactionalId string,
+	idempotence bool, metadata map[string]string) *ProducerAcl {
+
+	return &ProducerAcl{
+		Id:              producerAclId(project, principal, topics),
+		Project:         project,
+		Principal:       principal,
+		Topics:          topics,
+		TransactionalId: transactionalId,
+		Idempotence:     idempotence,
+		Metadata:        metadata,
+	}
+}
+
+// producerAclId identifies a producer by project#principal, followed by #topic1,topic2 with its sorted topics
+// when it has any, so producers of the same principal on different topics get distinct ids.
+func producerAclId(project string, principal string, topics []string) string {
+	if len(topics) == 0 {
+		return fmt.Sprintf("%s#%s", project, principal)
 	}
+	return fmt.Sprintf("%s#%s#%s", project, principal, sortedList(topics))
+}
+
+// sortedList joins the sorted names with commas.
+func sortedList(names []string) string {
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
 }
 
 type KafkaConnectAcl struct {
+	AclRestrictions
 	Id                string
 	Principal         string
 	Group             string
@@ -99,13 +201,61 @@ func NewKafkaConnectAcl(principal string, group string, readTopics []string, wri
 	}
 }
 
+type KafkaAcl struct {
+	Id           string
+	ResourceType string
+	ResourceName string
+	PatternType  string
+	Principal    string
+	Host         string
+	Operation    string
+	Permission   string
+}
+
+func NewKafkaAcl(resourceType string, resourceName string, patternType string, principal string,
+	host string, operation string, permission string) *KafkaAcl {
+
+	return &KafkaAcl{
+		Id: fmt.Sprintf("%s#%s#%s#%s#%s#%s#%s", resourceType, resourceName, patternType,
+			principal, host, operation, permission),
+		ResourceType: resourceType,
+		ResourceName: resourceName,
+		PatternType:  patternType,
+		Principal:    principal,
+		Host:         host,
+		Operation:    operation,
+		Permission:   permission,
+	}
+}
+
+// ParseKafkaAclId builds a KafkaAcl out of an id with the format
+// resource_type#resource_name#pattern_type#principal#host#operation#permission
+func ParseKafkaAclId(id string) (*KafkaAcl, error) {
+	parts := strings.Split(id, "#")
+	if len(parts) != 7 {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected resource_type#resource_name#pattern_type#principal#host#operation#permission", id)
+	}
+	return NewKafkaAcl(parts[0], parts[1], parts[2], parts[3], parts[4], parts[5], parts[6]), nil
+}
+
 type KafkaConnector struct {
 	Name   string
 	Config map[string]interface{}
 }
 
-func NewKafkaCluster(bootstrapServers string, config Config, kafkaConnectClient KafkaConnectCluster) *KafkaCluster {
-	return &KafkaCluster{BootstrapServers: []string{bootstrapServers}, Config: config, KafkaConnectClient: kafkaConnectClient}
+func NewKafkaCluster(bootstrapServers string, config Config, kafkaConnectClient KafkaConnectCluster, schemaRegistryClient *SchemaRegistryCluster) *KafkaCluster {
+	return &KafkaCluster{BootstrapServers: []string{bootstrapServers}, Config: config, KafkaConnectClient: kafkaConnectClient, SchemaRegistryClient: schemaRegistryClient}
+}
+
+// ErrSchemaRegistryNotConfigured is returned when a Schema Registry call is made without a schema_registry provider block.
+var ErrSchemaRegistryNotConfigured = errors.New("schema_registry is not configured in the provider")
+
+// SchemaRegistry returns the Schema Registry client, or ErrSchemaRegistryNotConfigured if there is none.
+func (k *KafkaCluster) SchemaRegistry() (*SchemaRegistryCluster, error) {
+	if k.SchemaRegistryClient == nil {
+		return nil, ErrSchemaRegistryNotConfigured
+	}
+	return k.SchemaRegistryClient, nil
 }
 
 func (c *Config) newConfig() (*sarama.Config, error) {
@@ -193,6 +343,136 @@ func (k *KafkaCluster) ListTopics(ctx context.Context, topic string) (topics []T
 	return acc, nil
 }
 
+type TopicPartition struct {
+	Id              int32
+	Leader          int32
+	Replicas        []int32
+	Isr             []int32
+	OfflineReplicas []int32
+	EarliestOffset  int64
+	LatestOffset    int64
+	Size            int64
+}
+
+func (tp TopicPartition) IsUnderReplicated() bool {
+	return len(tp.Isr) < len(tp.Replicas)
+}
+
+func (k KafkaCluster) newClient() (sarama.Client, error) {
+	var config, err = k.Config.newConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := sarama.NewClient(k.BootstrapServers, config)
+	if err != nil {
+		log.Printf("[ERROR] Error connecting to Kafka %s", k.BootstrapServers)
+		return nil, err
+	}
+	return client, nil
+}
+
+func (k *KafkaCluster) DescribeTopicPartitions(ctx context.Context, topic string) ([]TopicPartition, error) {
+	kafkaClient, err := k.newClient()
+	if err != nil {
+		return nil, err
+	}
+	adminClient, err := sarama.NewClusterAdminFromClient(kafkaClient)
+	if err != nil {
+		kafkaClient.Close()
+		log.Printf("[ERROR] Error connecting to Kafka %s", k.BootstrapServers)
+		return nil, err
+	}
+	// closing the admin client closes the underlying client as well
+	defer adminClient.Close()
+
+	metadata, err := adminClient.DescribeTopics([]string{topic})
+	if err != nil {
+		log.Printf("[ERROR] Error describing topic %s in Kafka %s", topic, k.BootstrapServers)
+		return nil, err
+	}
+	if len(metadata) == 0 {
+		return nil, fmt.Errorf("no metadata returned for topic %s", topic)
+	}
+	if metadata[0].Err != sarama.ErrNoError {
+		return nil, metadata[0].Err
+	}
+
+	sizes, err := topicPartitionSizes(adminClient, topic)
+	if err != nil {
+		log.Printf("[WARN] Could not retrieve the log dirs for topic %s: %s", topic, err)
+	}
+
+	partitions := make([]TopicPartition, 0, len(metadata[0].Partitions))
+	for _, p := range metadata[0].Partitions {
+		partition := TopicPartition{
+			Id:              p.ID,
+			Leader:          p.Leader,
+			Replicas:        p.Replicas,
+			Isr:             p.Isr,
+			OfflineReplicas: p.OfflineReplicas,
+			EarliestOffset:  -1,
+			LatestOffset:    -1,
+			Size:            sizes[partitionReplica{partition: p.ID, broker: p.Leader}],
+		}
+
+		if p.Leader >= 0 {
+			if partition.EarliestOffset, err = kafkaClient.GetOffset(topic, p.ID, sarama.OffsetOldest); err != nil {
+				log.Printf("[WARN] Could not retrieve the earliest offset for %s-%d: %s", topic, p.ID, err)
+				partition.EarliestOffset = -1
+			}
+			if partition.LatestOffset, err = kafkaClient.GetOffset(topic, p.ID, sarama.OffsetNewest); err != nil {
+				log.Printf("[WARN] Could not retrieve the latest offset for %s-%d: %s", topic, p.ID, err)
+				partition.LatestOffset = -1
+			}
+		}
+		partitions = append(partitions, partition)
+	}
+
+	sort.Slice(partitions, func(i, j int) bool {
+		return partitions[i].Id < partitions[j].Id
+	})
+
+	return partitions, nil
+}
+
+type partitionReplica struct {
+	partition int32
+	broker    int32
+}
+
+// topicPartitionSizes returns the on disk size of each replica of the given topic, keyed by partition and broker.
+func topicPartitionSizes(adminClient sarama.ClusterAdmin, topic string) (map[partitionReplica]int64, error) {
+	sizes := make(map[partitionReplica]int64)
+
+	brokers, _, err := adminClient.DescribeCluster()
+	if err != nil {
+		return sizes, err
+	}
+	brokerIds := make([]int32, len(brokers))
+	for i, broker := range brokers {
+		brokerIds[i] = broker.ID()
+	}
+
+	logDirs, err := adminClient.DescribeLogDirs(brokerIds)
+	for brokerId, dirs := range logDirs {
+		for _, dir := range dirs {
+			for _, t := range dir.Topics {
+				if t.Topic != topic {
+					continue
+				}
+				for _, p := range t.Partitions {
+					if p.IsTemporary {
+						continue
+					}
+					sizes[partitionReplica{partition: p.PartitionID, broker: brokerId}] += p.Size
+				}
+			}
+		}
+	}
+	return sizes, err
+}
+
 func (k *KafkaCluster) DeleteTopic(ctx context.Context, topicName string) error {
 	adminClient, err := k.newAdminClient()
 	if err != nil {
@@ -288,185 +568,166 @@ func (k KafkaCluster) IsAClusterAcl(acl sarama.ResourceAcls) bool {
 	return acl.ResourceType == sarama.AclResourceCluster
 }
 
+// AclCreationFailure describes a binding the cluster refused to create.
+type AclCreationFailure struct {
+	Resource AclResourceInfo
+	Err      error
+}
+
+// AclCreationError aggregates all the bindings that failed within a single CreateAcls request.
+type AclCreationError struct {
+	Failures []AclCreationFailure
+}
+
+func (e AclCreationError) Error() string {
+	messages := make([]string, len(e.Failures))
+	for i, failure := range e.Failures {
+		messages[i] = fmt.Sprintf("%s: %s", DescribeAclResource(failure.Resource), failure.Err)
+	}
+	return fmt.Sprintf("%d ACL(s) could not be created: %s", len(e.Failures), strings.Join(messages, "; "))
+}
+
+// DescribeAclResource returns a human readable representation of a single acl binding.
+func DescribeAclResource(r AclResourceInfo) string {
+	return fmt.Sprintf("(principal=%s, host=%s, operation=%s, permission=%s) on %s %s (%s)",
+		r.Acl.Principal, r.Acl.Host, r.Acl.Operation.String(), r.Acl.PermissionType.String(),
+		r.Resource.ResourceType.String(), r.Resource.ResourceName, r.Resource.ResourcePatternType.String())
+}
+
+// ApplyAcls creates all the bindings with a single CreateAcls request. If any of the bindings fails,
+// the ones that were created are removed again, so the cluster is left as it was, and an AclCreationError
+// is returned. Bindings already present before the request, shared with other resources or created by hand,
+// are never rolled back.
 func (k *KafkaCluster) ApplyAcls(resources AclResources) error {
-	adminClient, err := k.newAdminClient()
+	if len(resources.Resources) == 0 {
+		return nil
+	}
+
+	existing, err := k.FindAcls(resources)
 	if err != nil {
-		log.Printf("[ERROR] Error connecting to Kafka %s", k.BootstrapServers)
 		return err
 	}
-	defer adminClient.Close()
-
-	for _, resource := range resources.Resources {
-		adminClient.CreateACL(resource.Resource, resource.Acl)
+	existingSet := make(map[AclResourceInfo]bool, len(existing.Resources))
+	for _, resource := range existing.Resources {
+		existingSet[resource] = true
 	}
-	return nil
-}
 
-func (k *KafkaCluster) CreateConsumerAcl(consumerAcl ConsumerAcl) (*ConsumerAcl, error) {
 	adminClient, err := k.newAdminClient()
 	if err != nil {
 		log.Printf("[ERROR] Error connecting to Kafka %s", k.BootstrapServers)
-		return nil, err
+		return err
 	}
 	defer adminClient.Close()
 
-	resource := sarama.Resource{
-		ResourceName:        consumerAcl.Project,
-		ResourceType:        sarama.AclResourceTopic,
-		ResourcePatternType: sarama.AclPatternPrefixed,
+	request := &sarama.CreateAclsRequest{
+		Version:      1,
+		AclCreations: make([]*sarama.AclCreation, len(resources.Resources)),
+	}
+	for i, resource := range resources.Resources {
+		request.AclCreations[i] = &sarama.AclCreation{Resource: resource.Resource, Acl: resource.Acl}
 	}
 
-	operations := []sarama.AclOperation{sarama.AclOperationDescribe, sarama.AclOperationRead}
+	controller, err := adminClient.Controller()
+	if err != nil {
+		return err
+	}
 
-	for _, operation := range operations {
-		acl := sarama.Acl{
-			Principal:      consumerAcl.Principal,
-			Host:           "*",
-			Operation:      operation,
-			PermissionType: sarama.AclPermissionAllow,
-		}
-		adminClient.CreateACL(resource, acl)
+	response, err := controller.CreateAcls(request)
+	if err != nil {
+		log.Printf("[ERROR] Error creating ACLs in Kafka %s", k.BootstrapServers)
+		return err
 	}
 
-	resource = sarama.Resource{
-		ResourceName:        consumerAcl.Group,
-		ResourceType:        sarama.AclResourceGroup,
-		ResourcePatternType: sarama.AclPatternLiteral,
+	failures := make([]AclCreationFailure, 0)
+	created := make([]AclResourceInfo, 0, len(resources.Resources))
+	for i, resource := range resources.Resources {
+		if i >= len(response.AclCreationResponses) {
+			failures = append(failures, AclCreationFailure{Resource: resource, Err: fmt.Errorf("no response received")})
+			continue
+		}
+		creation := response.AclCreationResponses[i]
+		if creation.Err != sarama.ErrNoError {
+			err := error(creation.Err)
+			if creation.ErrMsg != nil && *creation.ErrMsg != "" {
+				err = fmt.Errorf("%s: %s", creation.Err, *creation.ErrMsg)
+			}
+			failures = append(failures, AclCreationFailure{Resource: resource, Err: err})
+			continue
+		}
+		if !existingSet[resource] {
+			created = append(created, resource)
+		}
 	}
 
-	acl := sarama.Acl{
-		Principal:      consumerAcl.Principal,
-		Host:           "*",
-		Operation:      sarama.AclOperationRead,
-		PermissionType: sarama.AclPermissionAllow,
+	if len(failures) == 0 {
+		return nil
 	}
 
-	adminClient.CreateACL(resource, acl)
+	log.Printf("[WARN] %d ACL(s) failed, rolling back %d created ACL(s)", len(failures), len(created))
+	for _, resource := range created {
+		if err := deleteAcl(adminClient, resource.Resource, resource.Acl); err != nil {
+			log.Printf("[ERROR] Could not roll back %s: %s", DescribeAclResource(resource), err)
+		}
+	}
 
-	return &consumerAcl, nil
+	return AclCreationError{Failures: failures}
 }
 
-func (k *KafkaCluster) DeleteConsumerAcl(consumerAcl ConsumerAcl) error {
-	adminClient, err := k.newAdminClient()
+func (k *KafkaCluster) CreateConsumerAcl(consumerAcl ConsumerAcl) (*ConsumerAcl, error) {
+	resources, err := KafkaAclsBuilder{Client: k}.ConsumerAclsBuilder(consumerAcl)
 	if err != nil {
-		log.Printf("[ERROR] Error connecting to Kafka %s", k.BootstrapServers)
-		return err
+		return nil, err
 	}
-	defer adminClient.Close()
 
-	var ops = []sarama.AclOperation{sarama.AclOperationDescribe, sarama.AclOperationRead}
-	for op := range ops {
-		var filter = sarama.AclFilter{
-			ResourceName:              &consumerAcl.Project,
-			ResourceType:              sarama.AclResourceTopic,
-			Principal:                 &consumerAcl.Principal,
-			Operation:                 sarama.AclOperation(op),
-			PermissionType:            sarama.AclPermissionAllow,
-			ResourcePatternTypeFilter: sarama.AclPatternPrefixed,
-		}
-		log.Printf("[DEBUG] Deleting ACL(s) for filter %o", filter)
-		m, err := adminClient.DeleteACL(filter, false)
-		log.Printf("[DEBUG] Deleted ACL(s) %d", len(m))
-		if err != nil {
-			log.Printf("[ERROR] Error deleting ACLs from Kafka %s", k.BootstrapServers)
-			return err
-		}
+	if err := k.ApplyAcls(resources); err != nil {
+		return nil, err
 	}
 
-	var filterGroup = sarama.AclFilter{
-		ResourceName:              &consumerAcl.Group,
-		ResourceType:              sarama.AclResourceGroup,
-		Principal:                 &consumerAcl.Principal,
-		Operation:                 sarama.AclOperationRead,
-		PermissionType:            sarama.AclPermissionAllow,
-		ResourcePatternTypeFilter: sarama.AclPatternLiteral,
-	}
-	log.Printf("[DEBUG] Deleting ACL(s) for filter %o", filterGroup)
-	m, err := adminClient.DeleteACL(filterGroup, false)
-	log.Printf("[DEBUG] Deleted ACL(s) %d", len(m))
+	return &consumerAcl, nil
+}
+
+func (k *KafkaCluster) DeleteConsumerAcl(consumerAcl ConsumerAcl) error {
+	resources, err := KafkaAclsBuilder{Client: k}.ConsumerAclsBuilder(consumerAcl)
 	if err != nil {
-		log.Printf("[ERROR] Error deleting ACLs from Kafka %s", k.BootstrapServers)
 		return err
 	}
-	return nil
+
+	return k.DeleteAcls(resources)
 }
 
 func (k *KafkaCluster) CreateKafkaStreamsAcl(kStreamsAcl KafkaStreamsAcl) (*KafkaStreamsAcl, error) {
-	adminClient, err := k.newAdminClient()
+	resources, err := KafkaAclsBuilder{Client: k}.KafkaStreamsAclsBuilder(kStreamsAcl)
 	if err != nil {
-		log.Printf("[ERROR] Error connecting to Kafka %s", k.BootstrapServers)
 		return nil, err
 	}
-	defer adminClient.Close()
-
-	resources, acls := createTopicAcls(kStreamsAcl.ReadTopics, kStreamsAcl.Principal, sarama.AclOperationRead)
-	for i, resource := range resources {
-		adminClient.CreateACL(resource, acls[i])
-	}
 
-	resources, acls = createTopicAcls(kStreamsAcl.WriteTopics, kStreamsAcl.Principal, sarama.AclOperationWrite)
-	for i, resource := range resources {
-		adminClient.CreateACL(resource, acls[i])
+	if err := k.ApplyAcls(resources); err != nil {
+		return nil, err
 	}
 
-	resource, acl := createKStreamAcl(kStreamsAcl.Project, kStreamsAcl.Principal, sarama.AclResourceTopic, sarama.AclOperationAll)
-	adminClient.CreateACL(resource, acl)
-	resource, acl = createKStreamAcl(kStreamsAcl.Project, kStreamsAcl.Principal, sarama.AclResourceGroup, sarama.AclOperationRead)
-	adminClient.CreateACL(resource, acl)
-
 	return &kStreamsAcl, nil
 }
 
 func (k *KafkaCluster) CreateKafkaConnectAcl(kConnectAcl KafkaConnectAcl, b KafkaAclsBuilder) (*KafkaConnectAcl, error) {
-	adminClient, err := k.newAdminClient()
+	resources, err := b.KafkaConnectAclsBuilder(kConnectAcl)
 	if err != nil {
-		log.Printf("[ERROR] Error connecting to Kafka %s", k.BootstrapServers)
 		return nil, err
 	}
-	defer adminClient.Close()
 
-	resources, err := b.KafkaConnectAclsBuilder(kConnectAcl)
-	if err != nil {
+	if err := k.ApplyAcls(resources); err != nil {
 		return nil, err
 	}
-	for _, resource := range resources.Resources {
-		adminClient.CreateACL(resource.Resource, resource.Acl)
-	}
+
 	return &kConnectAcl, nil
 }
 
 func (k *KafkaCluster) DeleteKafkaConnectAcl(kConnectAcl KafkaConnectAcl, b KafkaAclsBuilder) error {
-	adminClient, err := k.newAdminClient()
-	if err != nil {
-		log.Printf("[ERROR] Error connecting to Kafka %s", k.BootstrapServers)
-		return err
-	}
-	defer adminClient.Close()
-
 	resources, err := b.KafkaConnectAclsBuilder(kConnectAcl)
 	if err != nil {
 		return err
 	}
-	for _, resource := range resources.Resources {
 
-		var filter = sarama.AclFilter{
-			ResourceName:              &resource.Resource.ResourceName,
-			ResourceType:              resource.Resource.ResourceType,
-			Principal:                 &resource.Acl.Principal,
-			Operation:                 resource.Acl.Operation,
-			PermissionType:            resource.Acl.PermissionType,
-			ResourcePatternTypeFilter: resource.Resource.ResourcePatternType,
-		}
-		log.Printf("[DEBUG] Deleting ACL(s) for filter %o", filter)
-		m, err := adminClient.DeleteACL(filter, false)
-		log.Printf("[DEBUG] Deleted ACL(s) %d", len(m))
-		if err != nil {
-			log.Printf("[ERROR] Error deleting ACLs from Kafka %s", k.BootstrapServers)
-			return err
-		}
-	}
-
-	return nil
+	return k.DeleteAcls(resources)
 }
 
 func createKStreamAcl(project string, principal string, resourceType sarama.AclResourceType, op sarama.AclOperation) (sarama.Resource, sarama.Acl) {
@@ -486,6 +747,11 @@ func createKStreamAcl(project string, principal string, resourceType sarama.AclR
 }
 
 func createTopicAcls(topics []string, principal string, op sarama.AclOperation) ([]sarama.Resource, []sarama.Acl) {
+	return createTopicAclsWithPattern(topics, principal, op, sarama.AclPatternLiteral)
+}
+
+func createTopicAclsWithPattern(topics []string, principal string, op sarama.AclOperation,
+	patternType sarama.AclResourcePatternType) ([]sarama.Resource, []sarama.Acl) {
 	var resources = make([]sarama.Resource, len(topics))
 	var acls = make([]sarama.Acl, len(topics))
 
@@ -494,7 +760,7 @@ func createTopicAcls(topics []string, principal string, op sarama.AclOperation)
 		resources[i] = sarama.Resource{
 			ResourceName:        topic,
 			ResourceType:        sarama.AclResourceTopic,
-			ResourcePatternType: sarama.AclPatternLiteral,
+			ResourcePatternType: patternType,
 		}
 
 		acls[i] = sarama.Acl{
@@ -508,29 +774,12 @@ func createTopicAcls(topics []string, principal string, op sarama.AclOperation)
 }
 
 func (k *KafkaCluster) DeleteKafkaStreamsAcl(kStreamsAcl KafkaStreamsAcl) error {
-	adminClient, err := k.newAdminClient()
+	resources, err := KafkaAclsBuilder{Client: k}.KafkaStreamsAclsBuilder(kStreamsAcl)
 	if err != nil {
-		log.Printf("[ERROR] Error connecting to Kafka %s", k.BootstrapServers)
 		return err
 	}
-	defer adminClient.Close()
-
-	resources, acls := createTopicAcls(kStreamsAcl.ReadTopics, kStreamsAcl.Principal, sarama.AclOperationRead)
-	for i, resource := range resources {
-		deleteAcl(adminClient, resource, acls[i])
-	}
 
-	resources, acls = createTopicAcls(kStreamsAcl.WriteTopics, kStreamsAcl.Principal, sarama.AclOperationWrite)
-	for i, resource := range resources {
-		deleteAcl(adminClient, resource, acls[i])
-	}
-
-	resource, acl := createKStreamAcl(kStreamsAcl.Project, kStreamsAcl.Principal, sarama.AclResourceTopic, sarama.AclOperationAll)
-	deleteAcl(adminClient, resource, acl)
-	resource, acl = createKStreamAcl(kStreamsAcl.Project, kStreamsAcl.Principal, sarama.AclResourceGroup, sarama.AclOperationRead)
-	deleteAcl(adminClient, resource, acl)
-
-	return nil
+	return k.DeleteAcls(resources)
 }
 
 func deleteAcl(adminClient sarama.ClusterAdmin, resource sarama.Resource, acl sarama.Acl) error {
@@ -538,6 +787,7 @@ func deleteAcl(adminClient sarama.ClusterAdmin, resource sarama.Resource, acl sa
 		ResourceName:              &resource.ResourceName,
 		ResourceType:              resource.ResourceType,
 		Principal:                 &acl.Principal,
+		Host:                      &acl.Host,
 		Operation:                 acl.Operation,
 		PermissionType:            acl.PermissionType,
 		ResourcePatternTypeFilter: resource.ResourcePatternType,
@@ -552,19 +802,134 @@ func deleteAcl(adminClient sarama.ClusterAdmin, resource sarama.Resource, acl sa
 	return nil
 }
 
-func (k KafkaCluster) ListAcls(principal string) ([]sarama.ResourceAcls, error) {
+func (k *KafkaCluster) DeleteAcls(resources AclResources) error {
+	adminClient, err := k.newAdminClient()
+	if err != nil {
+		log.Printf("[ERROR] Error connecting to Kafka %s", k.BootstrapServers)
+		return err
+	}
+	defer adminClient.Close()
+
+	for _, resource := range resources.Resources {
+		if err := deleteAcl(adminClient, resource.Resource, resource.Acl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
+// AclExists returns true if a binding matching exactly the given resource and acl is present in the cluster.
+func (k KafkaCluster) AclExists(resource AclResourceInfo) (bool, error) {
 	adminClient, err := k.newAdminClient()
 	if err != nil {
 		log.Printf("[ERROR] Error connecting to Kafka %s", k.BootstrapServers)
-		return nil, err
+		return false, err
 	}
 	defer adminClient.Close()
 
+	return aclExists(adminClient, resource)
+}
+
+// FindAcls returns the subset of the given bindings that are present in the cluster, the bindings of each
+// principal are fetched with a single DescribeAcls request.
+func (k KafkaCluster) FindAcls(resources AclResources) (AclResources, error) {
+	found := AclResources{Resources: make([]AclResourceInfo, 0, len(resources.Resources))}
+
+	present := make(map[AclResourceInfo]bool)
+	listed := make(map[string]bool)
+	for _, resource := range resources.Resources {
+		principal := resource.Acl.Principal
+		if listed[principal] {
+			continue
+		}
+		listed[principal] = true
+
+		principalAcls, err := k.ListAclResources(principal)
+		if err != nil {
+			return found, err
+		}
+		for _, principalAcl := range principalAcls.Resources {
+			present[principalAcl] = true
+		}
+	}
+
+	for _, resource := range resources.Resources {
+		if present[resource] {
+			found.Resources = append(found.Resources, resource)
+		}
+	}
+	return found, nil
+}
+
+// ListAclResources returns every binding of the principal as individual resources.
+func (k KafkaCluster) ListAclResources(principal string) (AclResources, error) {
+	result := AclResources{Resources: make([]AclResourceInfo, 0)}
+
+	foundAcls, err := k.ListAcls(principal)
+	if err != nil {
+		return result, err
+	}
+
+	for _, entity := range foundAcls {
+		for _, acl := range entity.Acls {
+			if acl.Principal != principal {
+				continue
+			}
+			result.Resources = append(result.Resources, AclResourceInfo{Resource: entity.Resource, Acl: *acl})
+		}
+	}
+	return result, nil
+}
+
+func aclExists(adminClient sarama.ClusterAdmin, resource AclResourceInfo) (bool, error) {
 	filter := sarama.AclFilter{
-		ResourceType: sarama.AclResourceAny,
-		Principal:    &principal,
+		ResourceType:              resource.Resource.ResourceType,
+		ResourceName:              &resource.Resource.ResourceName,
+		ResourcePatternTypeFilter: resource.Resource.ResourcePatternType,
+		Principal:                 &resource.Acl.Principal,
+		Host:                      &resource.Acl.Host,
+		Operation:                 resource.Acl.Operation,
+		PermissionType:            resource.Acl.PermissionType,
+	}
+
+	foundAcls, err := adminClient.ListAcls(filter)
+	if err != nil {
+		return false, err
+	}
+
+	for _, entity := range foundAcls {
+		if entity.Resource != resource.Resource {
+			continue
+		}
+		for _, acl := range entity.Acls {
+			if *acl == resource.Acl {
+				return true, nil
+			}
+		}
 	}
+	return false, nil
+}
+
+func (k KafkaCluster) ListAcls(principal string) ([]sarama.ResourceAcls, error) {
+	filter := sarama.AclFilter{
+		ResourceType:              sarama.AclResourceAny,
+		ResourcePatternTypeFilter: sarama.AclPatternAny,
+		Principal:                 &principal,
+		Operation:                 sarama.AclOperationAny,
+		PermissionType:            sarama.AclPermissionAny,
+	}
+
+	return k.DescribeAcls(filter)
+}
+
+func (k KafkaCluster) DescribeAcls(filter sarama.AclFilter) ([]sarama.ResourceAcls, error) {
+
+	adminClient, err := k.newAdminClient()
+	if err != nil {
+		log.Printf("[ERROR] Error connecting to Kafka %s", k.BootstrapServers)
+		return nil, err
+	}
+	defer adminClient.Close()
 
 	return adminClient.ListAcls(filter)
 }
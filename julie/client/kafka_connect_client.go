@@ -3,10 +3,13 @@ package client
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -86,6 +89,19 @@ func (kc KafkaConnectCluster) doPutRequest(url string, bodyData []byte) (*http.R
 	return kc.Client.Do(req)
 }
 
+func (kc KafkaConnectCluster) doPatchRequest(url string, bodyData []byte) (*http.Response, error) {
+
+	req, err := http.NewRequest(http.MethodPatch, url, bytes.NewBuffer(bodyData))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return kc.Client.Do(req)
+}
+
 func (kc KafkaConnectCluster) GetClusterInfo() (*ClusterInfoResponse, error) {
 
 	response, err := kc.doGetRequest(kc.Url)
@@ -133,6 +149,13 @@ func (kc KafkaConnectCluster) GetConnector(name string) (*GetConnectorResponse,
 	}
 	defer response.Body.Close()
 
+	if response.StatusCode == 404 {
+		return nil, ErrConnectorNotFound
+	}
+	if response.StatusCode >= 400 {
+		return nil, connectResponseError(response, "read the connector "+name)
+	}
+
 	var getConnectorResponse GetConnectorResponse
 
 	if err := json.NewDecoder(response.Body).Decode(&getConnectorResponse); err != nil {
@@ -144,8 +167,9 @@ func (kc KafkaConnectCluster) GetConnector(name string) (*GetConnectorResponse,
 }
 
 type ConnectorCreateRequest struct {
-	Name   string                 `json:"name"`
-	Config map[string]interface{} `json:"config"`
+	Name         string                 `json:"name"`
+	Config       map[string]interface{} `json:"config"`
+	InitialState string                 `json:"initial_state,omitempty"`
 }
 
 type GetConnectorResponse struct {
@@ -169,24 +193,85 @@ func (kc KafkaConnectCluster) AddConnector(c ConnectorCreateRequest) (*Connector
 	return kc.AddOrUpdateConnector(c)
 }
 
+// ErrConnectorNotFound is returned when Kafka Connect does not know the requested connector.
+var ErrConnectorNotFound = errors.New("connector not found")
+
+// ConnectErrorResponse is the body Kafka Connect returns along with an error status code.
+type ConnectErrorResponse struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+// connectResponseError builds the error of a failed request, including the message returned by Kafka Connect.
+func connectResponseError(response *http.Response, action string) error {
+	var errorResponse ConnectErrorResponse
+	if err := json.NewDecoder(response.Body).Decode(&errorResponse); err != nil || errorResponse.Message == "" {
+		return fmt.Errorf("something happened while trying to %s, response Code = %d", action, response.StatusCode)
+	}
+	return fmt.Errorf("something happened while trying to %s, response Code = %d: %s", action, response.StatusCode, errorResponse.Message)
+}
+
 func (kc KafkaConnectCluster) AddOrUpdateConnector(c ConnectorCreateRequest) (*ConnectorCreateResponse, error) {
 	connectorsUrl := kc.Url + "/connectors/" + c.Name + "/config"
-	body, err := json.Marshal(c.Config)
+	body, err := json.Marshal(NormalizeConnectorConfig(c.Config))
 	if err != nil {
 		return nil, err
 	}
 	response, err := kc.doPutRequest(connectorsUrl, body)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer response.Body.Close()
 
 	if response.StatusCode == 409 {
 		errorCode := fmt.Errorf("a rebalance is in place, please check your Kafka Connect cluster")
 		return nil, errorCode
 	}
-	if response.StatusCode > 400 {
-		errorCode := fmt.Errorf("something happened while trying to create a connector, response Code = %d", response.StatusCode)
-		return nil, errorCode
+	if response.StatusCode >= 400 {
+		return nil, connectResponseError(response, "create or update the connector "+c.Name)
 	}
 
+	var connectorCreateResponse ConnectorCreateResponse
+	if err := json.NewDecoder(response.Body).Decode(&connectorCreateResponse); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return &connectorCreateResponse, nil
+}
+
+// CreateConnector creates a connector in its initial state, RUNNING, PAUSED or STOPPED. Setting an initial state
+// requires Kafka Connect 3.5 or newer.
+func (kc KafkaConnectCluster) CreateConnector(c ConnectorCreateRequest) (*ConnectorCreateResponse, error) {
+	connectorsUrl := kc.Url + "/connectors"
+	body, err := json.Marshal(struct {
+		Name         string            `json:"name"`
+		Config       map[string]string `json:"config"`
+		InitialState string            `json:"initial_state,omitempty"`
+	}{
+		Name:         c.Name,
+		Config:       NormalizeConnectorConfig(c.Config),
+		InitialState: c.InitialState,
+	})
+	if err != nil {
+		return nil, err
+	}
+	response, err := kc.doPostRequest(connectorsUrl, body)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
 	defer response.Body.Close()
+
+	if response.StatusCode == 409 {
+		errorCode := fmt.Errorf("a rebalance is in place, or the connector %s already exists, please check your Kafka Connect cluster", c.Name)
+		return nil, errorCode
+	}
+	if response.StatusCode >= 400 {
+		return nil, connectResponseError(response, "create the connector "+c.Name)
+	}
+
 	var connectorCreateResponse ConnectorCreateResponse
 	if err := json.NewDecoder(response.Body).Decode(&connectorCreateResponse); err != nil {
 		log.Println(err)
@@ -217,6 +302,368 @@ func (kc KafkaConnectCluster) DeleteConnector(name string) error {
 	return nil
 }
 
+type ConnectorStatusResponse struct {
+	Name      string          `json:"name"`
+	Connector ConnectorStatus `json:"connector"`
+	Tasks     []TaskStatus    `json:"tasks"`
+	Type      string          `json:"type"`
+}
+
+type ConnectorStatus struct {
+	State    string `json:"state"`
+	WorkerId string `json:"worker_id"`
+	Trace    string `json:"trace"`
+}
+
+type TaskStatus struct {
+	Id       int64  `json:"id"`
+	State    string `json:"state"`
+	WorkerId string `json:"worker_id"`
+	Trace    string `json:"trace"`
+}
+
+// FailedTasks returns the tasks of the connector in the FAILED state.
+func (s ConnectorStatusResponse) FailedTasks() []TaskStatus {
+	failed := make([]TaskStatus, 0)
+	for _, task := range s.Tasks {
+		if task.State == "FAILED" {
+			failed = append(failed, task)
+		}
+	}
+	return failed
+}
+
+// IsRunning is true when the connector and all its tasks are RUNNING. A RUNNING connector without tasks, such as
+// one with no partition or table to process yet, is running.
+func (s ConnectorStatusResponse) IsRunning() bool {
+	if s.Connector.State != "RUNNING" {
+		return false
+	}
+	for _, task := range s.Tasks {
+		if task.State != "RUNNING" {
+			return false
+		}
+	}
+	return true
+}
+
+func (kc KafkaConnectCluster) GetConnectorStatus(name string) (*ConnectorStatusResponse, error) {
+	statusUrl := kc.Url + "/connectors/" + name + "/status"
+	response, err := kc.doGetRequest(statusUrl)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == 404 {
+		return nil, ErrConnectorNotFound
+	}
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("something happened while trying to read the connector %s status, response Code = %d", name, response.StatusCode)
+	}
+
+	var statusResponse ConnectorStatusResponse
+	if err := json.NewDecoder(response.Body).Decode(&statusResponse); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return &statusResponse, nil
+}
+
+// PauseConnector suspends the connector and its tasks.
+func (kc KafkaConnectCluster) PauseConnector(name string) error {
+	return kc.changeConnectorState(name, "pause")
+}
+
+// ResumeConnector resumes a paused or stopped connector.
+func (kc KafkaConnectCluster) ResumeConnector(name string) error {
+	return kc.changeConnectorState(name, "resume")
+}
+
+// StopConnector shuts down the connector tasks, keeping its config and offsets. Requires Kafka Connect 3.5 or newer.
+func (kc KafkaConnectCluster) StopConnector(name string) error {
+	return kc.changeConnectorState(name, "stop")
+}
+
+func (kc KafkaConnectCluster) changeConnectorState(name string, action string) error {
+	stateUrl := kc.Url + "/connectors/" + name + "/" + action
+	response, err := kc.doPutRequest(stateUrl, nil)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == 409 {
+		errorCode := fmt.Errorf("a rebalance is in place, please check your Kafka Connect cluster")
+		return errorCode
+	}
+	if response.StatusCode >= 400 {
+		errorCode := fmt.Errorf("something happened while trying to %s the connector %s, response Code = %d", action, name, response.StatusCode)
+		return errorCode
+	}
+
+	return nil
+}
+
+// RestartConnector restarts the connector instance, and its tasks when includeTasks is set. With onlyFailed only
+// the failed instances are restarted.
+func (kc KafkaConnectCluster) RestartConnector(name string, includeTasks bool, onlyFailed bool) error {
+	restartUrl := fmt.Sprintf("%s/connectors/%s/restart?includeTasks=%t&onlyFailed=%t", kc.Url, name, includeTasks, onlyFailed)
+	return kc.restart(restartUrl, "the connector "+name)
+}
+
+// RestartTask restarts a single task of the connector.
+func (kc KafkaConnectCluster) RestartTask(name string, taskId int64) error {
+	restartUrl := fmt.Sprintf("%s/connectors/%s/tasks/%d/restart", kc.Url, name, taskId)
+	return kc.restart(restartUrl, fmt.Sprintf("the task %d of connector %s", taskId, name))
+}
+
+func (kc KafkaConnectCluster) restart(restartUrl string, target string) error {
+	response, err := kc.doPostRequest(restartUrl, nil)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode == 409 {
+		errorCode := fmt.Errorf("a rebalance is in place, please check your Kafka Connect cluster")
+		return errorCode
+	}
+	if response.StatusCode >= 400 {
+		errorCode := fmt.Errorf("something happened while trying to restart %s, response Code = %d", target, response.StatusCode)
+		return errorCode
+	}
+
+	return nil
+}
+
+type ConnectorOffsets struct {
+	Offsets []ConnectorOffset `json:"offsets"`
+}
+
+// ConnectorOffset is a source partition and offset, or for sink connectors the kafka_topic and kafka_partition
+// with its kafka_offset.
+type ConnectorOffset struct {
+	Partition map[string]interface{} `json:"partition"`
+	Offset    map[string]interface{} `json:"offset"`
+}
+
+// GetConnectorOffsets returns the committed offsets of a connector. Requires Kafka Connect 3.6 or newer.
+func (kc KafkaConnectCluster) GetConnectorOffsets(name string) (*ConnectorOffsets, error) {
+	offsetsUrl := kc.Url + "/connectors/" + name + "/offsets"
+	response, err := kc.doGetRequest(offsetsUrl)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("something happened while trying to read the connector %s offsets, response Code = %d", name, response.StatusCode)
+	}
+
+	var offsets ConnectorOffsets
+	if err := json.NewDecoder(response.Body).Decode(&offsets); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return &offsets, nil
+}
+
+// AlterConnectorOffsets writes the offsets of a stopped connector. Requires Kafka Connect 3.6 or newer.
+func (kc KafkaConnectCluster) AlterConnectorOffsets(name string, offsets ConnectorOffsets) error {
+	offsetsUrl := kc.Url + "/connectors/" + name + "/offsets"
+	body, err := json.Marshal(offsets)
+	if err != nil {
+		return err
+	}
+	response, err := kc.doPatchRequest(offsetsUrl, body)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("something happened while trying to alter the connector %s offsets, response Code = %d", name, response.StatusCode)
+	}
+
+	return nil
+}
+
+// ResetConnectorOffsets removes all the offsets of a stopped connector. Requires Kafka Connect 3.6 or newer.
+func (kc KafkaConnectCluster) ResetConnectorOffsets(name string) error {
+	offsetsUrl := kc.Url + "/connectors/" + name + "/offsets"
+	response, err := kc.doDeleteRequest(offsetsUrl)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return fmt.Errorf("something happened while trying to reset the connector %s offsets, response Code = %d", name, response.StatusCode)
+	}
+
+	return nil
+}
+
+type ExpandedConnector struct {
+	Status ConnectorStatusResponse `json:"status"`
+	Info   ConnectorInfo           `json:"info"`
+}
+
+type ConnectorInfo struct {
+	Name   string              `json:"name"`
+	Config map[string]string   `json:"config"`
+	Tasks  []ConnectorTaskInfo `json:"tasks"`
+	Type   string              `json:"type"`
+}
+
+// GetConnectorsExpanded returns the status and info of every connector, by connector name.
+func (kc KafkaConnectCluster) GetConnectorsExpanded() (map[string]ExpandedConnector, error) {
+	connectorsUrl := kc.Url + "/connectors?expand=status&expand=info"
+	response, err := kc.doGetRequest(connectorsUrl)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("something happened while trying to list the connectors, response Code = %d", response.StatusCode)
+	}
+
+	var connectors map[string]ExpandedConnector
+	if err := json.NewDecoder(response.Body).Decode(&connectors); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return connectors, nil
+}
+
+// GetConnectorTopics returns the topics the connector has used since it was created or its topics were reset.
+func (kc KafkaConnectCluster) GetConnectorTopics(name string) ([]string, error) {
+	topicsUrl := kc.Url + "/connectors/" + name + "/topics"
+	response, err := kc.doGetRequest(topicsUrl)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("something happened while trying to read the connector %s topics, response Code = %d", name, response.StatusCode)
+	}
+
+	var topicsResponse map[string]struct {
+		Topics []string `json:"topics"`
+	}
+	if err := json.NewDecoder(response.Body).Decode(&topicsResponse); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return topicsResponse[name].Topics, nil
+}
+
+type ConnectorPlugin struct {
+	Class   string `json:"class"`
+	Type    string `json:"type"`
+	Version string `json:"version"`
+}
+
+type ConfigValidationResponse struct {
+	Name       string                   `json:"name"`
+	ErrorCount int                      `json:"error_count"`
+	Configs    []ConfigValidationResult `json:"configs"`
+}
+
+type ConfigValidationResult struct {
+	Value ConfigValidationValue `json:"value"`
+}
+
+type ConfigValidationValue struct {
+	Name   string   `json:"name"`
+	Errors []string `json:"errors"`
+}
+
+func (kc KafkaConnectCluster) GetConnectorPlugins() ([]ConnectorPlugin, error) {
+	pluginsUrl := kc.Url + "/connector-plugins"
+	response, err := kc.doGetRequest(pluginsUrl)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("something happened while trying to list the connector plugins, response Code = %d", response.StatusCode)
+	}
+
+	var plugins []ConnectorPlugin
+	if err := json.NewDecoder(response.Body).Decode(&plugins); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return plugins, nil
+}
+
+// ValidateConnectorConfig validates a connector config against its plugin, the errors of each field are
+// returned in the response configs.
+func (kc KafkaConnectCluster) ValidateConnectorConfig(class string, config map[string]interface{}) (*ConfigValidationResponse, error) {
+	validateUrl := kc.Url + "/connector-plugins/" + url.PathEscape(class) + "/config/validate"
+	body, err := json.Marshal(NormalizeConnectorConfig(config))
+	if err != nil {
+		return nil, err
+	}
+	response, err := kc.doPutRequest(validateUrl, body)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, fmt.Errorf("something happened while trying to validate a connector config, response Code = %d", response.StatusCode)
+	}
+
+	var validationResponse ConfigValidationResponse
+	if err := json.NewDecoder(response.Body).Decode(&validationResponse); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return &validationResponse, nil
+}
+
+// NormalizeConnectorConfig converts every config value to the string Kafka Connect stores and returns it as.
+func NormalizeConnectorConfig(config map[string]interface{}) map[string]string {
+	normalized := make(map[string]string, len(config))
+	for k, v := range config {
+		switch v := v.(type) {
+		case string:
+			normalized[k] = v
+		case float64:
+			normalized[k] = strconv.FormatFloat(v, 'f', -1, 64)
+		case float32:
+			normalized[k] = strconv.FormatFloat(float64(v), 'f', -1, 32)
+		case nil:
+			normalized[k] = ""
+		default:
+			normalized[k] = fmt.Sprint(v)
+		}
+	}
+	return normalized
+}
+
 func (c *ConnectorsResponse) UnmarshalJSON(p []byte) error {
 
 	var tmp []string
@@ -2,8 +2,12 @@ package client
 
 import (
 	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
 	julieTest "terraform-provider-julieops/julie/test"
 	"testing"
+	"time"
 )
 import "github.com/stretchr/testify/assert"
 
@@ -125,3 +129,166 @@ func TestKafkaConnectCluster_GetConnector(t *testing.T) {
 
 	assert.NotEmpty(t, getConnectorResponse.Name, "Name should be not empty")
 }
+
+func TestKafkaConnectCluster_GetConnectorsExpanded(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDockerWithPath(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true,
+		EnableKafkaConnect:   true,
+		RootPath:             julieTest.AbsoluteMountPath("docker/res/", "/../../"),
+	}, t)
+	defer close(ctx)
+
+	client := NewKafkaConnectClient(setup.KcContainer.URI)
+
+	var connector = ConnectorCreateRequest{
+		Name: "foo",
+		Config: map[string]interface{}{
+			"connector.class": "io.confluent.kafka.connect.datagen.DatagenConnector",
+			"kafka.topic":     "pageviews",
+			"quickstart":      "pageviews",
+			"tasks.max":       "1",
+		},
+	}
+
+	defer client.DeleteConnector("foo")
+
+	if _, err := client.AddOrUpdateConnector(connector); err != nil {
+		t.Errorf("Something happen while trying to create a connector : %s", err)
+	}
+
+	connectors, err := client.GetConnectorsExpanded()
+	if err != nil {
+		t.Errorf("Something happen while listing the connectors : %s", err)
+	}
+	assert.Contains(t, connectors, "foo", "The connector foo should be listed")
+	assert.Equal(t, "source", connectors["foo"].Info.Type, "The connector foo should be a source")
+	assert.Equal(t, "foo", connectors["foo"].Status.Name, "The connector status should be expanded")
+
+	if _, err := client.GetConnectorTopics("foo"); err != nil {
+		t.Errorf("Something happen while reading the connector topics : %s", err)
+	}
+}
+
+func TestKafkaConnectCluster_ValidateConnectorConfig(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDockerWithPath(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true,
+		EnableKafkaConnect:   true,
+		RootPath:             julieTest.AbsoluteMountPath("docker/res/", "/../../"),
+	}, t)
+	defer close(ctx)
+
+	client := NewKafkaConnectClient(setup.KcContainer.URI)
+
+	plugins, err := client.GetConnectorPlugins()
+	if err != nil {
+		t.Errorf("Something happen while listing the connector plugins : %s", err)
+	}
+	assert.NotEmpty(t, plugins, "Plugins should not be empty")
+
+	var connectorConfig = map[string]interface{}{
+		"name":            "foo",
+		"connector.class": "io.confluent.kafka.connect.datagen.DatagenConnector",
+		"tasks.max":       "not-a-number",
+	}
+	response, err := client.ValidateConnectorConfig("io.confluent.kafka.connect.datagen.DatagenConnector", connectorConfig)
+	if err != nil {
+		t.Errorf("Something happen while validating a connector config : %s", err)
+	}
+	assert.NotZero(t, response.ErrorCount, "An invalid tasks.max should be reported")
+}
+
+func TestKafkaConnectCluster_RestartConnector(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDockerWithPath(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true,
+		EnableKafkaConnect:   true,
+		RootPath:             julieTest.AbsoluteMountPath("docker/res/", "/../../"),
+	}, t)
+	defer close(ctx)
+
+	client := NewKafkaConnectClient(setup.KcContainer.URI)
+
+	var connectorConfig = map[string]interface{}{
+		"connector.class": "io.confluent.kafka.connect.datagen.DatagenConnector",
+		"kafka.topic":     "pageviews",
+		"quickstart":      "pageviews",
+		"tasks.max":       "1",
+	}
+	var connector = ConnectorCreateRequest{
+		Name:   "foo",
+		Config: connectorConfig,
+	}
+
+	defer client.DeleteConnector("foo")
+
+	if _, err := client.AddOrUpdateConnector(connector); err != nil {
+		t.Errorf("Something happen while trying to create a connector : %s", err)
+	}
+
+	// tasks are assigned asynchronously after the connector creation
+	for i := 0; i < 30; i++ {
+		if status, err := client.GetConnectorStatus("foo"); err == nil && len(status.Tasks) > 0 {
+			break
+		}
+		time.Sleep(time.Second)
+	}
+
+	if err := client.RestartConnector("foo", true, false); err != nil {
+		t.Errorf("Something happen while trying to restart the connector foo : %s", err)
+	}
+
+	if err := client.RestartTask("foo", 0); err != nil {
+		t.Errorf("Something happen while trying to restart the task 0 of connector foo : %s", err)
+	}
+
+	status, err := client.GetConnectorStatus("foo")
+	if err != nil {
+		t.Errorf("Something happen while trying to get the connector foo status : %s", err)
+	}
+	assert.Empty(t, status.FailedTasks(), "No task should have failed")
+}
+
+func TestNormalizeConnectorConfig(t *testing.T) {
+	normalized := NormalizeConnectorConfig(map[string]interface{}{
+		"tasks.max":    "1",
+		"max.interval": 100,
+		"iterations":   float64(10000000),
+		"enabled":      true,
+	})
+
+	assert.Equal(t, map[string]string{
+		"tasks.max":    "1",
+		"max.interval": "100",
+		"iterations":   "10000000",
+		"enabled":      "true",
+	}, normalized, "Every config value should be a string")
+}
+
+func TestConnectResponseError(t *testing.T) {
+	response := &http.Response{
+		StatusCode: 400,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"error_code":400,"message":"Connector configuration is invalid"}`)),
+	}
+	err := connectResponseError(response, "create or update the connector foo")
+	assert.EqualError(t, err, "something happened while trying to create or update the connector foo, response Code = 400: Connector configuration is invalid")
+
+	response = &http.Response{StatusCode: 500, Body: ioutil.NopCloser(strings.NewReader("oops"))}
+	err = connectResponseError(response, "create or update the connector foo")
+	assert.EqualError(t, err, "something happened while trying to create or update the connector foo, response Code = 500")
+}
+
+func TestConnectorStatusResponse_IsRunning(t *testing.T) {
+	status := ConnectorStatusResponse{Name: "foo", Connector: ConnectorStatus{State: "RUNNING"}}
+	assert.True(t, status.IsRunning(), "A running connector without tasks should be running")
+
+	status.Tasks = []TaskStatus{{Id: 0, State: "RUNNING"}, {Id: 1, State: "UNASSIGNED"}}
+	assert.False(t, status.IsRunning(), "A connector with a task not running yet should not be running")
+
+	status.Tasks[1].State = "RUNNING"
+	assert.True(t, status.IsRunning(), "A connector with all its tasks running should be running")
+
+	status.Connector.State = "PAUSED"
+	assert.False(t, status.IsRunning(), "A paused connector should not be running")
+}
@@ -0,0 +1,448 @@
+package client
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const schemaRegistryContentType = "application/vnd.schemaregistry.v1+json"
+
+// ErrSchemaNotFound is returned when the requested subject or version does not exist in the Schema Registry.
+var ErrSchemaNotFound = errors.New("schema not found")
+
+type SchemaRegistryCluster struct {
+	Url      string
+	Username string
+	Password string
+	Client   http.Client
+}
+
+type SchemaReference struct {
+	Name    string `json:"name"`
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+type RegisterSchemaRequest struct {
+	Schema     string            `json:"schema"`
+	SchemaType string            `json:"schemaType,omitempty"`
+	References []SchemaReference `json:"references,omitempty"`
+}
+
+type RegisterSchemaResponse struct {
+	Id int `json:"id"`
+}
+
+type SubjectSchema struct {
+	Subject    string            `json:"subject"`
+	Id         int               `json:"id"`
+	Version    int               `json:"version"`
+	Schema     string            `json:"schema"`
+	SchemaType string            `json:"schemaType"`
+	References []SchemaReference `json:"references"`
+}
+
+type CompatibilityCheckResponse struct {
+	IsCompatible bool     `json:"is_compatible"`
+	Messages     []string `json:"messages"`
+}
+
+type CompatibilityRequest struct {
+	Compatibility string `json:"compatibility"`
+}
+
+type CompatibilityResponse struct {
+	CompatibilityLevel string `json:"compatibilityLevel"`
+}
+
+type ModeRequest struct {
+	Mode string `json:"mode"`
+}
+
+type SchemaRegistryErrorResponse struct {
+	ErrorCode int    `json:"error_code"`
+	Message   string `json:"message"`
+}
+
+func NewSchemaRegistryClient(url string, username string, password string) *SchemaRegistryCluster {
+
+	defaultTimeout, _ := time.ParseDuration("30s")
+
+	client := http.Client{
+		Timeout: defaultTimeout,
+	}
+
+	return &SchemaRegistryCluster{
+		Url:      url,
+		Username: username,
+		Password: password,
+		Client:   client,
+	}
+}
+
+// TopicNameStrategy returns the subject of the key or value schema of a topic, <topic>-key or <topic>-value.
+func TopicNameStrategy(topic string, isKey bool) string {
+	if isKey {
+		return topic + "-key"
+	}
+	return topic + "-value"
+}
+
+// RecordNameStrategy returns the subject of a record, its fully qualified name.
+func RecordNameStrategy(recordName string) string {
+	return recordName
+}
+
+// TopicRecordNameStrategy returns the subject of a record within a topic, <topic>-<record>.
+func TopicRecordNameStrategy(topic string, recordName string) string {
+	return topic + "-" + recordName
+}
+
+func (sr SchemaRegistryCluster) doRequest(method string, url string, bodyData []byte) (*http.Response, error) {
+	req, err := http.NewRequest(method, url, bytes.NewBuffer(bodyData))
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	req.Header.Set("Accept", schemaRegistryContentType)
+	if bodyData != nil {
+		req.Header.Set("Content-Type", schemaRegistryContentType)
+	}
+	if sr.Username != "" {
+		req.SetBasicAuth(sr.Username, sr.Password)
+	}
+
+	return sr.Client.Do(req)
+}
+
+func (sr SchemaRegistryCluster) doGetRequest(url string) (*http.Response, error) {
+	return sr.doRequest(http.MethodGet, url, nil)
+}
+
+func (sr SchemaRegistryCluster) doPostRequest(url string, bodyData []byte) (*http.Response, error) {
+	return sr.doRequest(http.MethodPost, url, bodyData)
+}
+
+func (sr SchemaRegistryCluster) doPutRequest(url string, bodyData []byte) (*http.Response, error) {
+	return sr.doRequest(http.MethodPut, url, bodyData)
+}
+
+func (sr SchemaRegistryCluster) doDeleteRequest(url string) (*http.Response, error) {
+	return sr.doRequest(http.MethodDelete, url, nil)
+}
+
+// responseError turns an unsuccessful Schema Registry response into an error carrying the registry message.
+func responseError(response *http.Response, action string) error {
+	if response.StatusCode == http.StatusNotFound {
+		return ErrSchemaNotFound
+	}
+
+	var errorResponse SchemaRegistryErrorResponse
+	if err := json.NewDecoder(response.Body).Decode(&errorResponse); err != nil || errorResponse.Message == "" {
+		return fmt.Errorf("something happened while trying to %s, response Code = %d", action, response.StatusCode)
+	}
+	return fmt.Errorf("something happened while trying to %s, error Code = %d: %s", action, errorResponse.ErrorCode, errorResponse.Message)
+}
+
+func (sr SchemaRegistryCluster) subjectUrl(subject string) string {
+	return sr.Url + "/subjects/" + url.PathEscape(subject)
+}
+
+// settingUrl is the url of a /config or /mode setting, global when the subject is empty.
+func (sr SchemaRegistryCluster) settingUrl(setting string, subject string) string {
+	if subject == "" {
+		return sr.Url + "/" + setting
+	}
+	return sr.Url + "/" + setting + "/" + url.PathEscape(subject)
+}
+
+// RegisterSchema registers a schema under a subject, returning the id of the new or already existing schema.
+func (sr SchemaRegistryCluster) RegisterSchema(subject string, request RegisterSchemaRequest) (*RegisterSchemaResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := sr.doPostRequest(sr.subjectUrl(subject)+"/versions", body)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, responseError(response, "register a schema")
+	}
+
+	var registerResponse RegisterSchemaResponse
+	if err := json.NewDecoder(response.Body).Decode(&registerResponse); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return &registerResponse, nil
+}
+
+// GetSchema returns a version, or "latest", of the schema registered under a subject.
+func (sr SchemaRegistryCluster) GetSchema(subject string, version string) (*SubjectSchema, error) {
+	response, err := sr.doGetRequest(sr.subjectUrl(subject) + "/versions/" + version)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, responseError(response, "read a schema")
+	}
+
+	var subjectSchema SubjectSchema
+	if err := json.NewDecoder(response.Body).Decode(&subjectSchema); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	if subjectSchema.SchemaType == "" {
+		subjectSchema.SchemaType = "AVRO"
+	}
+
+	return &subjectSchema, nil
+}
+
+// GetSubjects lists, sorted, the subjects starting with a prefix, all subjects when the prefix is empty.
+func (sr SchemaRegistryCluster) GetSubjects(prefix string) ([]string, error) {
+	response, err := sr.doGetRequest(sr.Url + "/subjects")
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, responseError(response, "list the subjects")
+	}
+
+	var subjects []string
+	if err := json.NewDecoder(response.Body).Decode(&subjects); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	result := make([]string, 0)
+	for _, subject := range subjects {
+		if strings.HasPrefix(subject, prefix) {
+			result = append(result, subject)
+		}
+	}
+	sort.Strings(result)
+
+	return result, nil
+}
+
+// DeleteSubject soft deletes all versions of a subject, and permanently removes them when permanent is set.
+func (sr SchemaRegistryCluster) DeleteSubject(subject string, permanent bool) error {
+	response, err := sr.doDeleteRequest(sr.subjectUrl(subject))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return responseError(response, "delete a subject")
+	}
+
+	if !permanent {
+		return nil
+	}
+
+	response, err = sr.doDeleteRequest(sr.subjectUrl(subject) + "?permanent=true")
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return responseError(response, "permanently delete a subject")
+	}
+
+	return nil
+}
+
+// TestCompatibility checks a schema against the latest version registered under a subject, the registry
+// incompatibility messages are returned when it is not compatible.
+func (sr SchemaRegistryCluster) TestCompatibility(subject string, request RegisterSchemaRequest) (*CompatibilityCheckResponse, error) {
+	body, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	compatibilityUrl := sr.Url + "/compatibility/subjects/" + url.PathEscape(subject) + "/versions/latest?verbose=true"
+	response, err := sr.doPostRequest(compatibilityUrl, body)
+	if err != nil {
+		log.Println(err)
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return nil, responseError(response, "check a schema compatibility")
+	}
+
+	var checkResponse CompatibilityCheckResponse
+	if err := json.NewDecoder(response.Body).Decode(&checkResponse); err != nil {
+		log.Println(err)
+		return nil, err
+	}
+
+	return &checkResponse, nil
+}
+
+// GetCompatibility returns the compatibility level of a subject, or the global one when the subject is empty.
+func (sr SchemaRegistryCluster) GetCompatibility(subject string) (string, error) {
+	response, err := sr.doGetRequest(sr.settingUrl("config", subject))
+	if err != nil {
+		log.Println(err)
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return "", responseError(response, "read a compatibility level")
+	}
+
+	var compatibilityResponse CompatibilityResponse
+	if err := json.NewDecoder(response.Body).Decode(&compatibilityResponse); err != nil {
+		log.Println(err)
+		return "", err
+	}
+
+	return compatibilityResponse.CompatibilityLevel, nil
+}
+
+// SetCompatibility updates the compatibility level of a subject, or the global one when the subject is empty.
+func (sr SchemaRegistryCluster) SetCompatibility(subject string, compatibility string) error {
+	body, err := json.Marshal(CompatibilityRequest{Compatibility: compatibility})
+	if err != nil {
+		return err
+	}
+
+	response, err := sr.doPutRequest(sr.settingUrl("config", subject), body)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return responseError(response, "update a compatibility level")
+	}
+
+	return nil
+}
+
+// DeleteCompatibility removes the compatibility level of a subject, which falls back to the global one.
+func (sr SchemaRegistryCluster) DeleteCompatibility(subject string) error {
+	response, err := sr.doDeleteRequest(sr.settingUrl("config", subject))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return responseError(response, "delete a compatibility level")
+	}
+
+	return nil
+}
+
+// GetMode returns the mode of a subject, or the global one when the subject is empty.
+func (sr SchemaRegistryCluster) GetMode(subject string) (string, error) {
+	response, err := sr.doGetRequest(sr.settingUrl("mode", subject))
+	if err != nil {
+		log.Println(err)
+		return "", err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return "", responseError(response, "read a mode")
+	}
+
+	var modeResponse ModeRequest
+	if err := json.NewDecoder(response.Body).Decode(&modeResponse); err != nil {
+		log.Println(err)
+		return "", err
+	}
+
+	return modeResponse.Mode, nil
+}
+
+// SetMode updates the mode of a subject, or the global one when the subject is empty. Force allows switching
+// to IMPORT while schemas are registered.
+func (sr SchemaRegistryCluster) SetMode(subject string, mode string, force bool) error {
+	body, err := json.Marshal(ModeRequest{Mode: mode})
+	if err != nil {
+		return err
+	}
+
+	modeUrl := sr.settingUrl("mode", subject)
+	if force {
+		modeUrl = modeUrl + "?force=true"
+	}
+
+	response, err := sr.doPutRequest(modeUrl, body)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return responseError(response, "update a mode")
+	}
+
+	return nil
+}
+
+// DeleteMode removes the mode of a subject, which falls back to the global one.
+func (sr SchemaRegistryCluster) DeleteMode(subject string) error {
+	response, err := sr.doDeleteRequest(sr.settingUrl("mode", subject))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode >= 400 {
+		return responseError(response, "delete a mode")
+	}
+
+	return nil
+}
+
+// NormalizeSchema returns a canonical form of a schema text, used to compare schemas regardless of their formatting.
+func NormalizeSchema(schema string, schemaType string) string {
+	if strings.EqualFold(schemaType, "PROTOBUF") {
+		return strings.Join(strings.Fields(schema), " ")
+	}
+
+	var value interface{}
+	if err := json.Unmarshal([]byte(schema), &value); err != nil {
+		return strings.TrimSpace(schema)
+	}
+	normalized, err := json.Marshal(value)
+	if err != nil {
+		return strings.TrimSpace(schema)
+	}
+	return string(normalized)
+}
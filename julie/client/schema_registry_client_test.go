@@ -0,0 +1,81 @@
+package client
+
+import (
+	"context"
+	"errors"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+import "github.com/stretchr/testify/assert"
+
+func TestSchemaRegistryCluster_RegisterSchema(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDockerWithPath(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true,
+		RootPath:             julieTest.AbsoluteMountPath("docker/res/", "/../../"),
+	}, t)
+	defer close(ctx)
+
+	client := NewSchemaRegistryClient(setup.SrContainer.URI, "", "")
+	subject := TopicNameStrategy("users", false)
+
+	response, err := client.RegisterSchema(subject, RegisterSchemaRequest{
+		Schema: `{"type": "record", "name": "User", "fields": [{"name": "name", "type": "string"}]}`,
+	})
+	if err != nil {
+		t.Errorf("Something happen while registering a schema: %s", err)
+	}
+
+	latest, err := client.GetSchema(subject, "latest")
+	if err != nil {
+		t.Errorf("Something happen while reading a schema: %s", err)
+	}
+
+	assert.Equal(t, response.Id, latest.Id, "The latest schema should be the registered one")
+	assert.Equal(t, "AVRO", latest.SchemaType, "The schema type should default to AVRO")
+
+	if err := client.DeleteSubject(subject, true); err != nil {
+		t.Errorf("Something happen while deleting a subject: %s", err)
+	}
+
+	_, err = client.GetSchema(subject, "latest")
+	assert.True(t, errors.Is(err, ErrSchemaNotFound), "A deleted subject should not be found")
+}
+
+func TestSchemaRegistryCluster_Compatibility(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDockerWithPath(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true,
+		RootPath:             julieTest.AbsoluteMountPath("docker/res/", "/../../"),
+	}, t)
+	defer close(ctx)
+
+	client := NewSchemaRegistryClient(setup.SrContainer.URI, "", "")
+
+	if err := client.SetCompatibility("users-value", "FULL"); err != nil {
+		t.Errorf("Something happen while setting a compatibility level: %s", err)
+	}
+
+	compatibility, err := client.GetCompatibility("users-value")
+	if err != nil {
+		t.Errorf("Something happen while reading a compatibility level: %s", err)
+	}
+	assert.Equal(t, "FULL", compatibility, "The subject compatibility level should be the updated one")
+
+	global, err := client.GetCompatibility("")
+	if err != nil {
+		t.Errorf("Something happen while reading the global compatibility level: %s", err)
+	}
+	assert.Equal(t, "BACKWARD", global, "The global compatibility level should be unchanged")
+}
+
+func TestNormalizeSchema(t *testing.T) {
+	assert.Equal(t,
+		NormalizeSchema(`{"type": "string"}`, "AVRO"),
+		NormalizeSchema("{\n  \"type\" : \"string\"\n}", "AVRO"),
+		"Formatting should not change an AVRO schema")
+	assert.Equal(t,
+		NormalizeSchema("syntax = \"proto3\";\nmessage A {}", "PROTOBUF"),
+		NormalizeSchema("syntax = \"proto3\";  message A {}", "PROTOBUF"),
+		"Whitespaces should not change a PROTOBUF schema")
+}
@@ -0,0 +1,159 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/Shopify/sarama"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"sort"
+	"terraform-provider-julieops/julie/client"
+)
+
+func dataSourceKafkaAcls() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKafkaAclsRead,
+		Schema: map[string]*schema.Schema{
+			"principal": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the bindings of this principal.",
+			},
+			"resource_type": {
+				Type:     schema.TypeString,
+				Optional: true,
+				Default:  "Any",
+				ValidateFunc: validation.StringInSlice([]string{
+					"Any", "Topic", "Group", "Cluster", "TransactionalID", "DelegationToken",
+				}, true),
+				Description: "Only return the bindings on this resource type.",
+			},
+			"resource_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the bindings on this resource name.",
+			},
+			"pattern_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Any",
+				ValidateFunc: validation.StringInSlice([]string{"Any", "Match", "Literal", "Prefixed"}, true),
+				Description:  "The resource pattern filter. Match returns every binding affecting the resource name, including prefixed and wildcard ones.",
+			},
+			"operation": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "Any",
+				ValidateFunc: validation.StringInSlice(append([]string{"Any"}, aclOperationNames...), true),
+				Description:  "Only return the bindings for this operation.",
+			},
+			"acls": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The matching ACL bindings.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"resource_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"resource_name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"pattern_type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"principal": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"host": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"operation": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"permission": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKafkaAclsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	cluster := m.(*client.KafkaCluster)
+
+	principal := d.Get("principal").(string)
+	resourceName := d.Get("resource_name").(string)
+
+	filter := sarama.AclFilter{
+		PermissionType: sarama.AclPermissionAny,
+	}
+	if err := filter.ResourceType.UnmarshalText([]byte(d.Get("resource_type").(string))); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := filter.ResourcePatternTypeFilter.UnmarshalText([]byte(d.Get("pattern_type").(string))); err != nil {
+		return diag.FromErr(err)
+	}
+	if err := filter.Operation.UnmarshalText([]byte(d.Get("operation").(string))); err != nil {
+		return diag.FromErr(err)
+	}
+	if principal != "" {
+		filter.Principal = &principal
+	}
+	if resourceName != "" {
+		filter.ResourceName = &resourceName
+	}
+
+	foundAcls, err := cluster.DescribeAcls(filter)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	acls := make([]map[string]interface{}, 0)
+	for _, entity := range foundAcls {
+		for _, acl := range entity.Acls {
+			acls = append(acls, map[string]interface{}{
+				"resource_type": entity.ResourceType.String(),
+				"resource_name": entity.ResourceName,
+				"pattern_type":  entity.ResourcePatternType.String(),
+				"principal":     acl.Principal,
+				"host":          acl.Host,
+				"operation":     acl.Operation.String(),
+				"permission":    acl.PermissionType.String(),
+			})
+		}
+	}
+
+	// the broker returns the bindings in no particular order, sort them to keep the list stable between reads
+	sort.Slice(acls, func(i, j int) bool {
+		return fmt.Sprint(acls[i]) < fmt.Sprint(acls[j])
+	})
+
+	log.Printf("[DEBUG] dataSourceKafkaAclsRead: found %d ACL(s)", len(acls))
+
+	result := make([]interface{}, len(acls))
+	for i, acl := range acls {
+		result[i] = acl
+	}
+	if err := d.Set("acls", result); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(fmt.Sprintf("%s#%s#%s#%s#%s", principal, d.Get("resource_type"), resourceName,
+		d.Get("pattern_type"), d.Get("operation")))
+
+	return diags
+}
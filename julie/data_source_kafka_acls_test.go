@@ -0,0 +1,54 @@
+package julie
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccKafkaAclsDataSource(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaGenericAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), testDataSourceAcls),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.julieops_kafka_acls.auditor", "acls.#", "1"),
+					resource.TestCheckResourceAttr("data.julieops_kafka_acls.auditor", "acls.0.operation", "Describe"),
+					resource.TestCheckResourceAttr("data.julieops_kafka_acls.matching", "acls.#", "1"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testDataSourceAcls = `
+resource "julieops_kafka_acl" "auditor" {
+  resource_type = "Topic"
+  resource_name = "audit."
+  pattern_type  = "Prefixed"
+  principal     = "User:auditor"
+  operation     = "Describe"
+}
+
+data "julieops_kafka_acls" "auditor" {
+  principal = julieops_kafka_acl.auditor.principal
+}
+
+data "julieops_kafka_acls" "matching" {
+  resource_type = "Topic"
+  resource_name = "audit.events"
+  pattern_type  = "Match"
+  principal     = julieops_kafka_acl.auditor.principal
+}
+`
@@ -0,0 +1,50 @@
+package julie
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"terraform-provider-julieops/julie/client"
+)
+
+func dataSourceKafkaConnectCluster() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKafkaConnectClusterRead,
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The Kafka Connect worker version.",
+			},
+			"commit": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The git commit of the Kafka Connect worker.",
+			},
+			"kafka_cluster_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The id of the Kafka cluster backing the Kafka Connect cluster.",
+			},
+		},
+	}
+}
+
+func dataSourceKafkaConnectClusterRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.KafkaCluster)
+
+	log.Printf("[DEBUG] reading the Kafka Connect cluster info from %s", c.KafkaConnectClient.Url)
+
+	info, err := c.KafkaConnectClient.GetClusterInfo()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("version", info.Version)
+	d.Set("commit", info.Commit)
+	d.Set("kafka_cluster_id", info.KafkaClusterId)
+	d.SetId(info.KafkaClusterId)
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package julie
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccKafkaConnectClusterDataSource(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true, EnableKafkaConnect: true,
+	}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, testDataSourceConnectCluster),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.julieops_kafka_connect_cluster.test", "version"),
+					resource.TestCheckResourceAttrSet("data.julieops_kafka_connect_cluster.test", "commit"),
+					resource.TestCheckResourceAttrSet("data.julieops_kafka_connect_cluster.test", "kafka_cluster_id"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testDataSourceConnectCluster = `
+data "julieops_kafka_connect_cluster" "test" {
+}
+`
@@ -0,0 +1,89 @@
+package julie
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"sort"
+	"terraform-provider-julieops/julie/client"
+)
+
+func dataSourceKafkaConnectPlugins() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKafkaConnectPluginsRead,
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"source", "sink"}, false),
+				Description:  "Only return the plugins of this type, source or sink.",
+			},
+			"plugins": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The connector plugins installed in the Kafka Connect cluster, sorted by class.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"class": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
+			},
+			"classes": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The sorted class names of the connector plugins.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKafkaConnectPluginsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.KafkaCluster)
+
+	pluginType := d.Get("type").(string)
+	log.Printf("[DEBUG] listing the connector plugins of type %s", pluginType)
+
+	plugins, err := c.KafkaConnectClient.GetConnectorPlugins()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Class < plugins[j].Class
+	})
+
+	result := make([]interface{}, 0)
+	classes := make([]string, 0)
+	for _, plugin := range plugins {
+		if pluginType != "" && plugin.Type != pluginType {
+			continue
+		}
+		result = append(result, map[string]interface{}{
+			"class":   plugin.Class,
+			"type":    plugin.Type,
+			"version": plugin.Version,
+		})
+		classes = append(classes, plugin.Class)
+	}
+
+	d.Set("plugins", result)
+	d.Set("classes", classes)
+	d.SetId("connector-plugins#" + pluginType)
+
+	return nil
+}
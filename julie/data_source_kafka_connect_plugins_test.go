@@ -0,0 +1,45 @@
+package julie
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccKafkaConnectPluginsDataSource(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true, EnableKafkaConnect: true,
+	}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, testDataSourceConnectPlugins),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.julieops_kafka_connect_plugins.all", "classes.*", "io.confluent.kafka.connect.datagen.DatagenConnector"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.julieops_kafka_connect_plugins.sources", "plugins.*", map[string]string{
+						"class": "io.confluent.kafka.connect.datagen.DatagenConnector",
+						"type":  "source",
+					}),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testDataSourceConnectPlugins = `
+data "julieops_kafka_connect_plugins" "all" {
+}
+
+data "julieops_kafka_connect_plugins" "sources" {
+  type = "source"
+}
+`
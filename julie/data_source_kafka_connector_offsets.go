@@ -0,0 +1,75 @@
+package julie
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"terraform-provider-julieops/julie/client"
+)
+
+func dataSourceKafkaConnectorOffsets() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKafkaConnectorOffsetsRead,
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the connector.",
+			},
+			"offsets": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The source partitions and offsets, or the sink consumer offsets, of the connector.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"partition": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The JSON encoded source partition, or kafka_topic and kafka_partition for sink connectors.",
+						},
+						"offset": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The JSON encoded source offset, or kafka_offset for sink connectors.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKafkaConnectorOffsetsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.KafkaCluster)
+
+	name := d.Get("name").(string)
+	log.Printf("[DEBUG] reading the offsets of connector %s", name)
+
+	connectorOffsets, err := c.KafkaConnectClient.GetConnectorOffsets(name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	offsets := make([]interface{}, len(connectorOffsets.Offsets))
+	for i, offset := range connectorOffsets.Offsets {
+		partition, err := json.Marshal(offset.Partition)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		value, err := json.Marshal(offset.Offset)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		offsets[i] = map[string]interface{}{
+			"partition": string(partition),
+			"offset":    string(value),
+		}
+	}
+
+	d.Set("offsets", offsets)
+	d.SetId(name)
+
+	return nil
+}
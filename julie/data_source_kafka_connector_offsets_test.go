@@ -0,0 +1,71 @@
+package julie
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"strconv"
+	"strings"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccKafkaConnectorOffsetsDataSource(t *testing.T) {
+	skipIfPlatformOlderThan(t, 7, 6)
+
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true, EnableKafkaConnect: true,
+	}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaConnectorDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, testDataSourceConnectorOffsets_initialOffsets),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "state", "stopped"),
+					resource.TestCheckResourceAttr("data.julieops_kafka_connector_offsets.test", "offsets.#", "1"),
+					resource.TestCheckResourceAttr("data.julieops_kafka_connector_offsets.test", "offsets.0.partition", `{"task.id":0}`),
+					resource.TestCheckResourceAttr("data.julieops_kafka_connector_offsets.test", "offsets.0.offset", `{"current.iteration":100}`),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testDataSourceConnectorOffsets_initialOffsets = `
+resource "julieops_kafka_connector" "test" {
+  name = "foo"
+  config = {
+    "connector.class" = "io.confluent.kafka.connect.datagen.DatagenConnector"
+    "kafka.topic" =     "pageviews"
+    "quickstart" =      "pageviews"
+    "tasks.max" =       "1"
+  }
+  state = "stopped"
+  initial_offsets {
+    partition = jsonencode({ "task.id" = 0 })
+    offset    = jsonencode({ "current.iteration" = 100 })
+  }
+}
+
+data "julieops_kafka_connector_offsets" "test" {
+  name = julieops_kafka_connector.test.name
+}
+`
+
+// skipIfPlatformOlderThan skips tests of Kafka Connect features missing from the Confluent Platform under test.
+func skipIfPlatformOlderThan(t *testing.T, major int, minor int) {
+	parts := strings.Split(julieTest.VersionTag, ".")
+	versionMajor, _ := strconv.Atoi(parts[0])
+	versionMinor, _ := strconv.Atoi(parts[1])
+	if versionMajor < major || (versionMajor == major && versionMinor < minor) {
+		t.Skipf("requires Confluent Platform %d.%d or newer, testing with %s", major, minor, julieTest.VersionTag)
+	}
+}
@@ -0,0 +1,108 @@
+package julie
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"sort"
+	"terraform-provider-julieops/julie/client"
+)
+
+func dataSourceKafkaConnectors() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceKafkaConnectorsRead,
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The sorted names of the connectors.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"connectors": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The connectors of the Kafka Connect cluster, sorted by name.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"type": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"connector_state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"task_states": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"topics": {
+							Type:     schema.TypeList,
+							Computed: true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceKafkaConnectorsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.KafkaCluster)
+
+	log.Printf("[DEBUG] listing the connectors of %s", c.KafkaConnectClient.Url)
+
+	expanded, err := c.KafkaConnectClient.GetConnectorsExpanded()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	names := make([]string, 0, len(expanded))
+	for name := range expanded {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	connectors := make([]interface{}, len(names))
+	for i, name := range names {
+		connector := expanded[name]
+
+		topics, err := c.KafkaConnectClient.GetConnectorTopics(name)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		sort.Strings(topics)
+
+		taskStates := make([]string, len(connector.Status.Tasks))
+		for j, task := range connector.Status.Tasks {
+			taskStates[j] = task.State
+		}
+
+		connectors[i] = map[string]interface{}{
+			"name":            name,
+			"type":            connector.Info.Type,
+			"connector_state": connector.Status.Connector.State,
+			"task_states":     taskStates,
+			"topics":          topics,
+		}
+	}
+
+	d.Set("names", names)
+	d.Set("connectors", connectors)
+	d.SetId(c.KafkaConnectClient.Url + "/connectors")
+
+	return nil
+}
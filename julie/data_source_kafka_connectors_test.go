@@ -0,0 +1,46 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccKafkaConnectorsDataSource(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true, EnableKafkaConnect: true,
+	}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaConnectorDelete,
+		Steps: []resource.TestStep{
+			{
+				Config:             cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector, "foo", "1")),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector, "foo", "1")+testDataSourceConnectors),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.julieops_kafka_connectors.test", "names.#", "1"),
+					resource.TestCheckResourceAttr("data.julieops_kafka_connectors.test", "names.0", "foo"),
+					resource.TestCheckResourceAttr("data.julieops_kafka_connectors.test", "connectors.0.type", "source"),
+					resource.TestCheckResourceAttrSet("data.julieops_kafka_connectors.test", "connectors.0.connector_state"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testDataSourceConnectors = `
+data "julieops_kafka_connectors" "test" {
+}
+`
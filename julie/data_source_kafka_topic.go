@@ -42,6 +42,78 @@ func dataSourceKafkaTopics() *schema.Resource {
 				Description: "A map of string k/v attributes.",
 				Elem:        schema.TypeString,
 			},
+			"under_replicated_partitions": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of partitions with fewer in-sync replicas than assigned replicas.",
+			},
+			"offline_partitions": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Number of partitions without an available leader.",
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Estimated size in bytes of the topic, as reported by the partition leaders log dirs.",
+			},
+			"partition_details": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Per partition health and size details.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"partition": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The partition id.",
+						},
+						"leader": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The broker id of the partition leader, -1 if the partition is offline.",
+						},
+						"replicas": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The broker ids of the assigned replicas.",
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+						"isr": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The broker ids of the in-sync replicas.",
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+						"offline_replicas": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Description: "The broker ids of the offline replicas.",
+							Elem:        &schema.Schema{Type: schema.TypeInt},
+						},
+						"under_replicated": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "True if the partition has fewer in-sync replicas than assigned replicas.",
+						},
+						"earliest_offset": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The earliest available offset, -1 if unknown.",
+						},
+						"latest_offset": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The latest offset (log end offset), -1 if unknown.",
+						},
+						"size": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "Estimated size in bytes of the partition leader replica.",
+						},
+					},
+				},
+			},
 		},
 	}
 }
@@ -61,7 +133,8 @@ func dataSourceKafkaTopicsRead(ctx context.Context, d *schema.ResourceData, m in
 
 	for _, topic := range topics {
 		log.Printf("DEBUG dataSourceKafkaTopicsRead: name=%s, topic=%s, len=%d", name, topic.Name, len(topics))
-		if topic.Name == "" {
+		// topics are listed by prefix, only the exact topic describes the data source
+		if topic.Name != name {
 			continue
 		}
 		d.Set("name", topic.Name)
@@ -72,5 +145,46 @@ func dataSourceKafkaTopicsRead(ctx context.Context, d *schema.ResourceData, m in
 	}
 	//d.SetId(strconv.FormatInt(time.Now().Unix(), 10))
 
+	if d.Id() == "" {
+		return diag.Errorf("topic %s not found", name)
+	}
+
+	partitions, err := cluster.DescribeTopicPartitions(ctx, name)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	underReplicated := 0
+	offline := 0
+	size := 0
+	details := make([]interface{}, len(partitions))
+	for i, partition := range partitions {
+		if partition.IsUnderReplicated() {
+			underReplicated++
+		}
+		if partition.Leader < 0 {
+			offline++
+		}
+		size += int(partition.Size)
+		details[i] = map[string]interface{}{
+			"partition":        int(partition.Id),
+			"leader":           int(partition.Leader),
+			"replicas":         int32SliceAsInterfaces(partition.Replicas),
+			"isr":              int32SliceAsInterfaces(partition.Isr),
+			"offline_replicas": int32SliceAsInterfaces(partition.OfflineReplicas),
+			"under_replicated": partition.IsUnderReplicated(),
+			"earliest_offset":  int(partition.EarliestOffset),
+			"latest_offset":    int(partition.LatestOffset),
+			"size":             int(partition.Size),
+		}
+	}
+
+	if err := d.Set("partition_details", details); err != nil {
+		return diag.FromErr(err)
+	}
+	d.Set("under_replicated_partitions", underReplicated)
+	d.Set("offline_partitions", offline)
+	d.Set("size", size)
+
 	return diags
 }
@@ -0,0 +1,129 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccKafkaTopicDataSourceExactName(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaTopicDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), testDataSourceTopic_prefixSibling),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.julieops_kafka_topic.exact", "name", "foo.exact"),
+					resource.TestCheckResourceAttr("data.julieops_kafka_topic.exact", "partitions", "1"),
+					resource.TestCheckResourceAttr("data.julieops_kafka_topic.exact", "partition_details.#", "1"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config:      cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), testDataSourceTopic_prefixSibling+testDataSourceTopic_missing),
+				ExpectError: regexp.MustCompile("topic foo.ex not found"),
+			},
+		},
+	})
+}
+
+func TestAccKafkaTopicDataSourcePartitionDetails(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	topicName := "foo.details"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaTopicDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testDataSourceTopic_partitionDetails, topicName)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaTopicDataSourceHealthy("data.julieops_kafka_topic.details", 2),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testDataSourceTopic_prefixSibling = `
+resource "julieops_kafka_topic" "exact" {
+  name               = "foo.exact"
+  replication_factor = 1
+  partitions         = 1
+}
+
+resource "julieops_kafka_topic" "sibling" {
+  name               = "foo.exact.sibling"
+  replication_factor = 1
+  partitions         = 3
+}
+
+data "julieops_kafka_topic" "exact" {
+  name       = julieops_kafka_topic.exact.name
+  depends_on = [julieops_kafka_topic.sibling]
+}
+`
+
+const testDataSourceTopic_missing = `
+data "julieops_kafka_topic" "missing" {
+  name       = "foo.ex"
+  depends_on = [julieops_kafka_topic.exact]
+}
+`
+
+const testDataSourceTopic_partitionDetails = `
+resource "julieops_kafka_topic" "test" {
+  name               = "%s"
+  replication_factor = 1
+  partitions         = 2
+}
+
+data "julieops_kafka_topic" "details" {
+  name = julieops_kafka_topic.test.name
+}
+`
+
+func testAccKafkaTopicDataSourceHealthy(name string, partitions int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resource, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("data source not found: %s", name)
+		}
+
+		details := resource.Primary.Attributes["partition_details.#"]
+		underReplicated := resource.Primary.Attributes["under_replicated_partitions"]
+		leader := resource.Primary.Attributes["partition_details.0.leader"]
+
+		if details != fmt.Sprintf("%d", partitions) {
+			return fmt.Errorf("data source %s with unexpected partition details count %s", name, details)
+		}
+
+		if underReplicated != "0" {
+			return fmt.Errorf("data source %s with unexpected under replicated partitions %s", name, underReplicated)
+		}
+
+		if leader == "-1" {
+			return fmt.Errorf("data source %s with an offline partition 0", name)
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,94 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"strconv"
+	"terraform-provider-julieops/julie/client"
+)
+
+func dataSourceSchema() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSchemaRead,
+		Schema: map[string]*schema.Schema{
+			"subject": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The subject the schema is registered under.",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Computed:    true,
+				Description: "The schema version, the latest one when not set.",
+			},
+			"schema_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The global id of the schema.",
+			},
+			"schema": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The schema definition.",
+			},
+			"schema_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The schema type, AVRO, PROTOBUF or JSON.",
+			},
+			"references": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The schemas referenced by this schema.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"subject": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"version": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSchemaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subject := d.Get("subject").(string)
+	version := "latest"
+	if v, ok := d.GetOk("version"); ok {
+		version = strconv.Itoa(v.(int))
+	}
+	log.Printf("[DEBUG] reading version %s of subject %s", version, subject)
+
+	subjectSchema, err := sr.GetSchema(subject, version)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("reading version %s of subject %s: %w", version, subject, err))
+	}
+
+	d.Set("version", subjectSchema.Version)
+	d.Set("schema_id", subjectSchema.Id)
+	d.Set("schema", subjectSchema.Schema)
+	d.Set("schema_type", subjectSchema.SchemaType)
+	d.Set("references", schemaReferencesAsInterfaces(subjectSchema.References))
+	d.SetId(fmt.Sprintf("%s#%d", subject, subjectSchema.Version))
+
+	return nil
+}
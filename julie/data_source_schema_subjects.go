@@ -0,0 +1,50 @@
+package julie
+
+import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"terraform-provider-julieops/julie/client"
+)
+
+func dataSourceSchemaSubjects() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceSchemaSubjectsRead,
+		Schema: map[string]*schema.Schema{
+			"prefix": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Only return the subjects starting with this prefix.",
+			},
+			"subjects": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The sorted list of matching subjects.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func dataSourceSchemaSubjectsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	prefix := d.Get("prefix").(string)
+	log.Printf("[DEBUG] listing the subjects with prefix %s", prefix)
+
+	subjects, err := sr.GetSubjects(prefix)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("subjects", subjects)
+	d.SetId("subjects#" + prefix)
+
+	return nil
+}
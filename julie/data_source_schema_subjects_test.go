@@ -0,0 +1,47 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccSchemaSubjectsDataSourcePrefix(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{EnableSchemaRegistry: true}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccSchemaDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI,
+					fmt.Sprintf(testResourceSchema_topic, testSchemaV1)+testDataSourceSchemaSubjects_prefix),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.julieops_schema_subjects.orders", "subjects.#", "1"),
+					resource.TestCheckResourceAttr("data.julieops_schema_subjects.orders", "subjects.0", "orders-value"),
+					resource.TestCheckResourceAttr("data.julieops_schema_subjects.none", "subjects.#", "0"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testDataSourceSchemaSubjects_prefix = `
+data "julieops_schema_subjects" "orders" {
+  prefix = "orders"
+  depends_on = [ julieops_schema.orders ]
+}
+
+data "julieops_schema_subjects" "none" {
+  prefix = "payments"
+  depends_on = [ julieops_schema.orders ]
+}
+`
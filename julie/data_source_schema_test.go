@@ -0,0 +1,51 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccSchemaDataSourceVersions(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{EnableSchemaRegistry: true}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccSchemaDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, fmt.Sprintf(testResourceSchema_topic, testSchemaV1)),
+			},
+			{
+				Config: cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI,
+					fmt.Sprintf(testResourceSchema_topic, testSchemaV2)+testDataSourceSchema_versions),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.julieops_schema.latest", "version", "2"),
+					resource.TestCheckResourceAttrPair("data.julieops_schema.latest", "schema_id", "julieops_schema.orders", "schema_id"),
+					resource.TestCheckResourceAttr("data.julieops_schema.first", "version", "1"),
+					resource.TestCheckResourceAttr("data.julieops_schema.first", "schema_type", "AVRO"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testDataSourceSchema_versions = `
+data "julieops_schema" "latest" {
+  subject = julieops_schema.orders.subject
+  depends_on = [ julieops_schema.orders ]
+}
+
+data "julieops_schema" "first" {
+  subject = julieops_schema.orders.subject
+  version = 1
+}
+`
@@ -1,8 +1,11 @@
 package julie
 
 import (
+	"encoding/json"
+	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
+	"strings"
 	"terraform-provider-julieops/julie/client"
 )
 
@@ -29,7 +32,7 @@ func interfaceAsTopic(d *schema.ResourceData) client.Topic {
 	}
 }
 
-func resourceAsConsumerAcl(d *schema.ResourceData) interface{} {
+func resourceAsConsumerAcl(d client.ResourceGetter) interface{} {
 
 	project := d.Get("project").(string)
 	principal := d.Get("principal").(string)
@@ -46,14 +49,61 @@ func resourceAsConsumerAcl(d *schema.ResourceData) interface{} {
 		}
 	}
 
-	return *client.NewConsumerAcl(project, principal, group, metaMap)
+	topics := interfaceArrayAsSlice(d.Get("topics").([]interface{}))
+	topicPrefixes := interfaceArrayAsSlice(d.Get("topic_prefixes").([]interface{}))
+
+	groups := make([]client.ConsumerGroup, 0)
+	for _, group := range d.Get("groups").([]interface{}) {
+		groupMap, ok := group.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := groupMap["name"].(string)
+		patternType, _ := groupMap["pattern_type"].(string)
+		groups = append(groups, client.ConsumerGroup{Name: name, PatternType: patternType})
+	}
+
+	acl := client.NewConsumerAcl(project, principal, group, topics, topicPrefixes, groups, metaMap)
+	acl.AclRestrictions = aclRestrictionsFrom(d)
+
+	return *acl
 }
 
-func resourceAsKafkaStreamsAcl(d *schema.ResourceData) interface{} {
+func resourceAsProducerAcl(d client.ResourceGetter) interface{} {
 	project := d.Get("project").(string)
 	principal := d.Get("principal").(string)
+	topics := d.Get("topics").([]interface{})
+	transactionalId := d.Get("transactional_id").(string)
+	idempotence := d.Get("idempotence").(bool)
+
+	metadata := d.Get("metadata").(map[string]interface{})
+
+	metaMap := make(map[string]string)
+	for k, v := range metadata {
+		switch v := v.(type) {
+		case string:
+			log.Printf("[DEBUG] resourceAsProducerAcl: config.key = %s, config.value = %s", k, v)
+			metaMap[k] = v
+		}
+	}
+
+	topicsArray := interfaceArrayAsSlice(topics)
+
+	acl := client.NewProducerAcl(project, principal, topicsArray, transactionalId, idempotence, metaMap)
+	acl.AclRestrictions = aclRestrictionsFrom(d)
+
+	return *acl
+}
+
+func resourceAsKafkaStreamsAcl(d client.ResourceGetter) interface{} {
+	project := d.Get("project").(string)
+	principal := d.Get("principal").(string)
+	applicationId := d.Get("application_id").(string)
 	readTopics := d.Get("read_topics").([]interface{})
 	writeTopics := d.Get("write_topics").([]interface{})
+	readTopicPrefixes := d.Get("read_topic_prefixes").([]interface{})
+	writeTopicPrefixes := d.Get("write_topic_prefixes").([]interface{})
+	exactlyOnce := d.Get("exactly_once").(bool)
 
 	metadata := d.Get("metadata").(map[string]interface{})
 
@@ -69,10 +119,17 @@ func resourceAsKafkaStreamsAcl(d *schema.ResourceData) interface{} {
 	readTopicsArray := interfaceArrayAsSlice(readTopics)
 	writeTopicsArray := interfaceArrayAsSlice(writeTopics)
 
-	return *client.NewKafkaStreamsAcl(project, principal, readTopicsArray, writeTopicsArray, metaMap)
+	readTopicPrefixesArray := interfaceArrayAsSlice(readTopicPrefixes)
+	writeTopicPrefixesArray := interfaceArrayAsSlice(writeTopicPrefixes)
+
+	acl := client.NewKafkaStreamsAcl(project, principal, applicationId, readTopicsArray, writeTopicsArray,
+		readTopicPrefixesArray, writeTopicPrefixesArray, exactlyOnce, metaMap)
+	acl.AclRestrictions = aclRestrictionsFrom(d)
+
+	return *acl
 }
 
-func resourceAsKafkaConnectAcl(d *schema.ResourceData) interface{} {
+func resourceAsKafkaConnectAcl(d client.ResourceGetter) interface{} {
 	principal := d.Get("principal").(string)
 	readTopics := d.Get("read_topics").([]interface{})
 	writeTopics := d.Get("write_topics").([]interface{})
@@ -96,7 +153,59 @@ func resourceAsKafkaConnectAcl(d *schema.ResourceData) interface{} {
 	readTopicsArray := interfaceArrayAsSlice(readTopics)
 	writeTopicsArray := interfaceArrayAsSlice(writeTopics)
 
-	return *client.NewKafkaConnectAcl(principal, group, readTopicsArray, writeTopicsArray, statusTopic, configsTopic, offsetTopic, topicCreate, metaMap)
+	acl := client.NewKafkaConnectAcl(principal, group, readTopicsArray, writeTopicsArray, statusTopic, configsTopic, offsetTopic, topicCreate, metaMap)
+	acl.AclRestrictions = aclRestrictionsFrom(d)
+
+	return *acl
+}
+
+func resourceAsKafkaAcl(d client.ResourceGetter) interface{} {
+	resourceType := d.Get("resource_type").(string)
+	resourceName := d.Get("resource_name").(string)
+	patternType := d.Get("pattern_type").(string)
+	principal := d.Get("principal").(string)
+	host := d.Get("host").(string)
+	operation := d.Get("operation").(string)
+	permission := d.Get("permission").(string)
+
+	return *client.NewKafkaAcl(resourceType, resourceName, patternType, principal, host, operation, permission)
+}
+
+func aclRestrictionsFrom(d client.ResourceGetter) client.AclRestrictions {
+	restrictions := client.AclRestrictions{
+		Hosts: interfaceArrayAsSlice(d.Get("hosts").([]interface{})),
+	}
+
+	for _, rule := range d.Get("deny").([]interface{}) {
+		ruleMap, ok := rule.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		restrictions.Deny = append(restrictions.Deny, client.AclDenyRule{
+			Operations:    interfaceArrayAsSlice(listValue(ruleMap["operations"])),
+			Topics:        interfaceArrayAsSlice(listValue(ruleMap["topics"])),
+			TopicPrefixes: interfaceArrayAsSlice(listValue(ruleMap["topic_prefixes"])),
+		})
+	}
+
+	return restrictions
+}
+
+func listValue(value interface{}) []interface{} {
+	if list, ok := value.([]interface{}); ok {
+		return list
+	}
+	return []interface{}{}
+}
+
+// priorState exposes the values of a resource before the change being applied.
+type priorState struct {
+	d *schema.ResourceData
+}
+
+func (p priorState) Get(key string) interface{} {
+	old, _ := p.d.GetChange(key)
+	return old
 }
 
 func interfaceArrayAsSlice(topics []interface{}) []string {
@@ -111,22 +220,163 @@ func interfaceArrayAsSlice(topics []interface{}) []string {
 	return topicsArray
 }
 
-func extractConnectorResource(d *schema.ResourceData) client.KafkaConnector {
+func int32SliceAsInterfaces(values []int32) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = int(v)
+	}
+	return result
+}
+
+// hiddenConfigValue is the value Kafka Connect returns in place of masked config values.
+const hiddenConfigValue = "[hidden]"
+
+func extractConnectorResource(d client.ResourceGetter) client.KafkaConnector {
 
 	name := d.Get("name").(string)
 	config := d.Get("config").(map[string]interface{})
+	sensitiveConfig := d.Get("config_sensitive").(map[string]interface{})
 
-	mapConfig := make(map[string]*string)
+	// values are not logged as config_sensitive may hold secrets
+	mergedConfig := make(map[string]interface{}, len(config)+len(sensitiveConfig))
 	for k, v := range config {
-		switch v := v.(type) {
-		case string:
-			log.Printf("extractConnectorResource: config.key = %s, config.value = %s", k, v)
-			mapConfig[k] = &v
-		}
+		log.Printf("[DEBUG] extractConnectorResource: config.key = %s", k)
+		mergedConfig[k] = v
+	}
+	for k, v := range sensitiveConfig {
+		mergedConfig[k] = v
 	}
 
 	return client.KafkaConnector{
 		Name:   name,
-		Config: config,
+		Config: mergedConfig,
+	}
+}
+
+// resourceAsConnectorOffsets decodes the JSON partitions and offsets of the initial_offsets blocks.
+func resourceAsConnectorOffsets(d client.ResourceGetter) (client.ConnectorOffsets, error) {
+	offsets := client.ConnectorOffsets{Offsets: make([]client.ConnectorOffset, 0)}
+	for _, block := range d.Get("initial_offsets").([]interface{}) {
+		blockMap, ok := block.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		var offset client.ConnectorOffset
+		if err := json.Unmarshal([]byte(blockMap["partition"].(string)), &offset.Partition); err != nil {
+			return offsets, fmt.Errorf("invalid initial_offsets partition: %w", err)
+		}
+		if err := json.Unmarshal([]byte(blockMap["offset"].(string)), &offset.Offset); err != nil {
+			return offsets, fmt.Errorf("invalid initial_offsets offset: %w", err)
+		}
+		offsets.Offsets = append(offsets.Offsets, offset)
+	}
+	return offsets, nil
+}
+
+// sensitiveConfigKeyParts are the parts of a config key, split on dots, dashes and underscores, naming a secret, as
+// in connection.password, aws.secret.access.key, oauth.token or sasl.jaas.config.
+var sensitiveConfigKeyParts = map[string]bool{
+	"password":    true,
+	"passwd":      true,
+	"secret":      true,
+	"token":       true,
+	"credential":  true,
+	"credentials": true,
+	"jaas":        true,
+	"apikey":      true,
+}
+
+// isSensitiveConfig tells whether an imported config entry holds a secret, either masked by Kafka Connect or
+// named as one.
+func isSensitiveConfig(key string, value string) bool {
+	if value == hiddenConfigValue {
+		return true
+	}
+	parts := strings.FieldsFunc(strings.ToLower(key), func(r rune) bool {
+		return r == '.' || r == '-' || r == '_'
+	})
+	for i, part := range parts {
+		if sensitiveConfigKeyParts[part] {
+			return true
+		}
+		// api.key or private.key, but not key.converter
+		if part == "key" && i > 0 && (parts[i-1] == "api" || parts[i-1] == "private" || parts[i-1] == "access") {
+			return true
+		}
+	}
+	return false
+}
+
+// splitConnectorConfig separates the config read from Kafka Connect into the config and config_sensitive
+// attributes, keeping the known value of the entries Kafka Connect masks as [hidden]. Entries Kafka Connect
+// injects, such as name, are ignored: only the managed keys are read back, or all but name on import, when the
+// entries masked or named as secrets go to config_sensitive.
+func splitConnectorConfig(d client.ResourceGetter, remoteConfig map[string]string) (map[string]string, map[string]string) {
+	config := d.Get("config").(map[string]interface{})
+	sensitiveConfig := d.Get("config_sensitive").(map[string]interface{})
+	imported := len(config) == 0 && len(sensitiveConfig) == 0
+
+	newConfig := make(map[string]string)
+	newSensitiveConfig := make(map[string]string)
+	for k, v := range remoteConfig {
+		if known, ok := sensitiveConfig[k]; ok {
+			if v == hiddenConfigValue {
+				v = known.(string)
+			}
+			newSensitiveConfig[k] = v
+			continue
+		}
+		known, ok := config[k]
+		if !ok && (!imported || k == "name") {
+			log.Printf("[DEBUG] splitConnectorConfig: ignoring the unmanaged config.key = %s", k)
+			continue
+		}
+		if imported && isSensitiveConfig(k, v) {
+			newSensitiveConfig[k] = v
+			continue
+		}
+		if ok && v == hiddenConfigValue {
+			v = known.(string)
+		}
+		newConfig[k] = v
+	}
+	return newConfig, newSensitiveConfig
+}
+
+func resourceAsRegisterSchemaRequest(d client.ResourceGetter) client.RegisterSchemaRequest {
+	request := client.RegisterSchemaRequest{
+		Schema:     d.Get("schema").(string),
+		SchemaType: d.Get("schema_type").(string),
+	}
+
+	// Schema Registry omits the type of AVRO schemas
+	if request.SchemaType == "AVRO" {
+		request.SchemaType = ""
+	}
+
+	for _, reference := range d.Get("references").([]interface{}) {
+		referenceMap, ok := reference.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		request.References = append(request.References, client.SchemaReference{
+			Name:    referenceMap["name"].(string),
+			Subject: referenceMap["subject"].(string),
+			Version: referenceMap["version"].(int),
+		})
+	}
+
+	return request
+}
+
+func schemaReferencesAsInterfaces(references []client.SchemaReference) []interface{} {
+	result := make([]interface{}, len(references))
+	for i, reference := range references {
+		result[i] = map[string]interface{}{
+			"name":    reference.Name,
+			"subject": reference.Subject,
+			"version": reference.Version,
+		}
 	}
+	return result
 }
@@ -36,16 +36,54 @@ func Provider() *schema.Provider {
 				Optional:    true,
 				Description: "The Kafka Connect cluster url(s)",
 			},
+			"schema_registry": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: "The Schema Registry connection settings",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"url": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The Schema Registry url",
+						},
+						"basic_auth_username": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Description: "The Schema Registry basic auth username",
+						},
+						"basic_auth_password": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							Description: "The Schema Registry basic auth password",
+						},
+					},
+				},
+			},
 		},
 		ResourcesMap: map[string]*schema.Resource{
-			"julieops_kafka_topic":        resourceKafkaTopic(),
-			"julieops_kafka_consumer_acl": resourceKafkaConsumerAcl(),
-			"julieops_kafka_streams_acl":  resourceKafkaStreamsAcl(),
-			"julieops_kafka_connect_acl":  resourceKafkaConnectAcl(),
-			"julieops_kafka_connector":    resourceKafkaConnector(),
+			"julieops_kafka_topic":          resourceKafkaTopic(),
+			"julieops_kafka_consumer_acl":   resourceKafkaConsumerAcl(),
+			"julieops_kafka_producer_acl":   resourceKafkaProducerAcl(),
+			"julieops_kafka_streams_acl":    resourceKafkaStreamsAcl(),
+			"julieops_kafka_connect_acl":    resourceKafkaConnectAcl(),
+			"julieops_kafka_connector":      resourceKafkaConnector(),
+			"julieops_kafka_acl":            resourceKafkaAcl(),
+			"julieops_schema":               resourceSchema(),
+			"julieops_schema_compatibility": resourceSchemaCompatibility(),
+			"julieops_schema_registry_mode": resourceSchemaRegistryMode(),
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"julieops_kafka_topic": dataSourceKafkaTopics(),
+			"julieops_kafka_topic":             dataSourceKafkaTopics(),
+			"julieops_kafka_acls":              dataSourceKafkaAcls(),
+			"julieops_schema":                  dataSourceSchema(),
+			"julieops_schema_subjects":         dataSourceSchemaSubjects(),
+			"julieops_kafka_connector_offsets": dataSourceKafkaConnectorOffsets(),
+			"julieops_kafka_connect_cluster":   dataSourceKafkaConnectCluster(),
+			"julieops_kafka_connect_plugins":   dataSourceKafkaConnectPlugins(),
+			"julieops_kafka_connectors":        dataSourceKafkaConnectors(),
 		},
 		ConfigureContextFunc: providerConfig,
 	}
@@ -78,7 +116,21 @@ func providerConfig(ctx context.Context, d *schema.ResourceData) (interface{}, d
 			kafkaConnectClient = client.NewKafkaConnectClient(kafkaConnectUrl)
 		}
 
-		cluster := client.NewKafkaCluster(bootstrapServers, config, *kafkaConnectClient)
+		var schemaRegistryClient *client.SchemaRegistryCluster
+
+		for _, block := range d.Get("schema_registry").([]interface{}) {
+			schemaRegistry, ok := block.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			schemaRegistryClient = client.NewSchemaRegistryClient(
+				schemaRegistry["url"].(string),
+				schemaRegistry["basic_auth_username"].(string),
+				schemaRegistry["basic_auth_password"].(string),
+			)
+		}
+
+		cluster := client.NewKafkaCluster(bootstrapServers, config, *kafkaConnectClient, schemaRegistryClient)
 		return cluster, diags
 	}
 	return nil, diags
@@ -77,6 +77,9 @@ func accTestProviderConfig() (*terraform.ResourceConfig, error) {
 		"sasl_password":     "kafka",
 		"sasl_mechanism":    "plain",
 		"kafka_connects":    "http://localhost:18083",
+		"schema_registry": []interface{}{
+			map[string]interface{}{"url": schemaRegistryServerFromEnv()},
+		},
 	}
 	return terraform.NewResourceConfigRaw(raw), nil
 }
@@ -88,3 +91,7 @@ func bootstrapServersFromEnv() string {
 func kafkaConnectServerFromEnv() string {
 	return "http://localhost:18083"
 }
+
+func schemaRegistryServerFromEnv() string {
+	return "http://localhost:8081"
+}
@@ -0,0 +1,184 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strings"
+	"terraform-provider-julieops/julie/client"
+)
+
+var aclOperationNames = []string{
+	"All", "Read", "Write", "Create", "Delete", "Alter", "Describe",
+	"ClusterAction", "DescribeConfigs", "AlterConfigs", "IdempotentWrite",
+}
+
+func resourceKafkaAcl() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKafkaAclCreate,
+		ReadContext:   resourceKafkaAclRead,
+		DeleteContext: resourceKafkaAclDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKafkaAclImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"resource_type": {
+				Type:     schema.TypeString,
+				Required: true,
+				ForceNew: true,
+				ValidateFunc: validation.StringInSlice([]string{
+					"Topic", "Group", "Cluster", "TransactionalID", "DelegationToken",
+				}, true),
+				DiffSuppressFunc: suppressCaseInsensitive,
+				Description:      "The type of the resource, one of Topic, Group, Cluster, TransactionalID or DelegationToken",
+			},
+			"resource_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the resource, use kafka-cluster for Cluster resources",
+			},
+			"pattern_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "Literal",
+				ValidateFunc:     validation.StringInSlice([]string{"Literal", "Prefixed"}, true),
+				DiffSuppressFunc: suppressCaseInsensitive,
+				Description:      "The resource pattern type, Literal or Prefixed",
+			},
+			"principal": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The user principal for this acl definition",
+			},
+			"host": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "*",
+				Description: "The host the principal is allowed (or denied) to connect from",
+			},
+			"operation": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				ValidateFunc:     validation.StringInSlice(aclOperationNames, true),
+				DiffSuppressFunc: suppressCaseInsensitive,
+				Description:      "The operation granted (or denied) by this acl",
+			},
+			"permission": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "Allow",
+				ValidateFunc:     validation.StringInSlice([]string{"Allow", "Deny"}, true),
+				DiffSuppressFunc: suppressCaseInsensitive,
+				Description:      "The permission type, Allow or Deny",
+			},
+		},
+	}
+}
+
+func resourceKafkaAclCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	kafkaClient := m.(*client.KafkaCluster)
+	builder := client.KafkaAclsBuilder{
+		Client: kafkaClient,
+	}
+
+	aclInterface, err := funcCreateAcl(kafkaClient, builder, d, resourceAsKafkaAcl, builder.KafkaAclBuilder)
+	if err != nil {
+		return aclDiagnostics(err)
+	}
+	acl := aclInterface.(client.KafkaAcl)
+	d.SetId(acl.Id)
+	return nil
+}
+
+func resourceKafkaAclRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	kafkaClient := m.(*client.KafkaCluster)
+	log.Printf("[DEBUG] resourceKafkaAclRead: KafkaAcl=%s", d.Id())
+
+	builder := client.KafkaAclsBuilder{
+		Client: kafkaClient,
+	}
+	resources, err := builder.KafkaAclBuilder(resourceAsKafkaAcl(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	exists, err := kafkaClient.AclExists(resources.Resources[0])
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if !exists {
+		log.Printf("[WARN] ACL %s not found, removing from state", d.Id())
+		d.SetId("")
+	}
+
+	return nil
+}
+
+func resourceKafkaAclDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*client.KafkaCluster)
+	builder := client.KafkaAclsBuilder{Client: c}
+
+	resources, err := builder.KafkaAclBuilder(resourceAsKafkaAcl(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	log.Printf("[DEBUG] Deleting Kafka ACL %s", d.Id())
+
+	err = c.DeleteAcls(resources)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+func resourceKafkaAclImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	acl, err := client.ParseKafkaAclId(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.Set("resource_type", acl.ResourceType)
+	d.Set("resource_name", acl.ResourceName)
+	d.Set("pattern_type", acl.PatternType)
+	d.Set("principal", acl.Principal)
+	d.Set("host", acl.Host)
+	d.Set("operation", acl.Operation)
+	d.Set("permission", acl.Permission)
+
+	c := m.(*client.KafkaCluster)
+	builder := client.KafkaAclsBuilder{Client: c}
+	resources, err := builder.KafkaAclBuilder(*acl)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := c.AclExists(resources.Resources[0])
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("no ACL found matching %s", d.Id())
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// suppressCaseInsensitive ignores casing differences, the ACL names are validated and parsed case-insensitively.
+func suppressCaseInsensitive(k, old, new string, d *schema.ResourceData) bool {
+	return strings.EqualFold(old, new)
+}
@@ -0,0 +1,243 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	"terraform-provider-julieops/julie/client"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccKafkaGenericAclCreate(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	principal := "User:describer"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaGenericAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testResourceGenericAcl, principal)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaGenericAclExist("julieops_kafka_acl.describe_cluster"),
+					testAccKafkaGenericAclExist("julieops_kafka_acl.deny_secrets"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      "julieops_kafka_acl.deny_secrets",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKafkaGenericAclCaseInsensitive(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaGenericAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testResourceGenericAcl_casing, "Topic", "Literal", "Read", "Allow")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaGenericAclExist("julieops_kafka_acl.casing"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config:             cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testResourceGenericAcl_casing, "topic", "literal", "read", "allow")),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func TestAccKafkaGenericAclCreateRejected(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaGenericAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config:      cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), testResourceGenericAcl_invalidCluster),
+				ExpectError: regexp.MustCompile("ACL could not be created"),
+			},
+		},
+	})
+}
+
+func TestAccKafkaAclApplyRollbackKeepsExisting(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	principal := "User:describer"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaGenericAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testResourceGenericAcl, principal)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaAclApplyRollback(principal),
+					testAccKafkaGenericAclExist("julieops_kafka_acl.describe_cluster"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+// testAccKafkaAclApplyRollback applies a batch mixing an existing, a new and an invalid binding, and checks
+// the failure only rolls back the new binding.
+func testAccKafkaAclApplyRollback(principal string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := testProvider.Meta().(*client.KafkaCluster)
+		builder := client.KafkaAclsBuilder{Client: c}
+
+		batch := client.AclResources{}
+		for _, acl := range []*client.KafkaAcl{
+			client.NewKafkaAcl("Cluster", "kafka-cluster", "Literal", principal, "*", "Describe", "Allow"),
+			client.NewKafkaAcl("Topic", "rollback", "Literal", principal, "*", "Read", "Allow"),
+			client.NewKafkaAcl("Cluster", "not-the-cluster", "Literal", principal, "*", "Describe", "Allow"),
+		} {
+			resources, err := builder.KafkaAclBuilder(*acl)
+			if err != nil {
+				return err
+			}
+			batch.Resources = append(batch.Resources, resources.Resources...)
+		}
+
+		if err := c.ApplyAcls(batch); err == nil {
+			return fmt.Errorf("expected the batch with an invalid cluster binding to fail")
+		}
+
+		found, err := c.FindAcls(batch)
+		if err != nil {
+			return err
+		}
+		if len(found.Resources) != 1 || found.Resources[0] != batch.Resources[0] {
+			return fmt.Errorf("expected only the existing binding to remain, found %v", found.Strings())
+		}
+		return nil
+	}
+}
+
+const testResourceGenericAcl_casing = `
+resource "julieops_kafka_acl" "casing" {
+  resource_type = "%s"
+  resource_name = "orders"
+  pattern_type  = "%s"
+  principal     = "User:casing"
+  operation     = "%s"
+  permission    = "%s"
+}
+`
+
+const testResourceGenericAcl_invalidCluster = `
+resource "julieops_kafka_acl" "invalid_cluster" {
+  resource_type = "Cluster"
+  resource_name = "not-the-cluster"
+  principal     = "User:describer"
+  operation     = "Describe"
+}
+`
+
+const testResourceGenericAcl = `
+resource "julieops_kafka_acl" "describe_cluster" {
+  resource_type = "Cluster"
+  resource_name = "kafka-cluster"
+  principal     = "%[1]s"
+  operation     = "Describe"
+}
+
+resource "julieops_kafka_acl" "deny_secrets" {
+  resource_type = "Topic"
+  resource_name = "secrets."
+  pattern_type  = "Prefixed"
+  principal     = "%[1]s"
+  operation     = "Read"
+  permission    = "Deny"
+}
+`
+
+func testAccKafkaGenericAclDelete(s *terraform.State) error {
+	c := testProvider.Meta().(*client.KafkaCluster)
+	builder := client.KafkaAclsBuilder{Client: c}
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "julieops_kafka_acl" {
+			continue
+		}
+		acl, err := client.ParseKafkaAclId(rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+		resources, err := builder.KafkaAclBuilder(*acl)
+		if err != nil {
+			return err
+		}
+		exists, err := c.AclExists(resources.Resources[0])
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("acl %s still exists", rs.Primary.ID)
+		}
+	}
+	return nil
+}
+
+func testAccKafkaGenericAclExist(resourceName string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resource, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("ACL not found: %s", resourceName)
+		}
+
+		c := testProvider.Meta().(*client.KafkaCluster)
+		acl, err := client.ParseKafkaAclId(resource.Primary.ID)
+		if err != nil {
+			return err
+		}
+		resources, err := client.KafkaAclsBuilder{Client: c}.KafkaAclBuilder(*acl)
+		if err != nil {
+			return err
+		}
+		exists, err := c.AclExists(resources.Resources[0])
+		if err != nil {
+			return err
+		}
+		if !exists {
+			return fmt.Errorf("acl %s not found in the cluster", resource.Primary.ID)
+		}
+
+		return nil
+	}
+}
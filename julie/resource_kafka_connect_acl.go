@@ -2,9 +2,12 @@ package julie
 
 import (
 	"context"
+	"fmt"
+	"github.com/Shopify/sarama"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
+	"strings"
 	"terraform-provider-julieops/julie/client"
 )
 
@@ -12,9 +15,11 @@ func resourceKafkaConnectAcl() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceKafkaConnectCreate,
 		ReadContext:   resourceKafkaConnectRead,
+		UpdateContext: resourceKafkaConnectUpdate,
 		DeleteContext: resourceKafkaConnectDelete,
+		CustomizeDiff: funcAclsCustomizeDiff(resourceAsKafkaConnectAcl, client.KafkaAclsBuilder{}.KafkaConnectAclsBuilder),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceKafkaConnectImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"principal": {
@@ -27,13 +32,11 @@ func resourceKafkaConnectAcl() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "connect-cluster",
-				ForceNew:    true,
 				Description: "The group used for this Kafka Connect deployment",
 			},
 			"read_topics": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -42,7 +45,6 @@ func resourceKafkaConnectAcl() *schema.Resource {
 			"write_topics": {
 				Type:     schema.TypeList,
 				Optional: true,
-				ForceNew: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -52,37 +54,35 @@ func resourceKafkaConnectAcl() *schema.Resource {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "connect-status",
-				ForceNew:    true,
 				Description: "The status topic used for the connect cluster",
 			},
 			"offset_topic": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "connect-offsets",
-				ForceNew:    true,
 				Description: "The offset topic used for the connect cluster",
 			},
 			"configs_topic": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				Default:     "connect-configs",
-				ForceNew:    true,
 				Description: "The configs topic used for the connect cluster",
 			},
 			"enable_topic_create": {
 				Type:        schema.TypeBool,
 				Optional:    true,
 				Default:     false,
-				ForceNew:    true,
 				Description: "True if the connect cluster can create their own topics",
 			},
 			"metadata": {
 				Type:        schema.TypeMap,
 				Optional:    true,
-				ForceNew:    true,
 				Description: "Map of optional values describing metadata information for this consumer",
 				Elem:        schema.TypeString,
 			},
+			"hosts":        hostsSchema(),
+			"deny":         denySchema(),
+			"acl_bindings": aclBindingsSchema(),
 		},
 	}
 }
@@ -93,9 +93,9 @@ func resourceKafkaConnectCreate(ctx context.Context, d *schema.ResourceData, m i
 		Client: kafkaClient,
 	}
 
-	aclInterface, err := funcCreateAcl(kafkaClient, builder, d, resourceAsKafkaConnectAcl, builder.KafkaConnectAclsBuilder)
+	aclInterface, err := funcCreateAclBundle(kafkaClient, builder, d, resourceAsKafkaConnectAcl, builder.KafkaConnectAclsBuilder)
 	if err != nil {
-		return diag.FromErr(err)
+		return aclDiagnostics(err)
 	}
 	acl := aclInterface.(client.KafkaConnectAcl)
 	d.SetId(acl.Id)
@@ -109,18 +109,32 @@ func resourceKafkaConnectRead(ctx context.Context, d *schema.ResourceData, m int
 	builder := client.KafkaAclsBuilder{
 		Client: kafkaClient,
 	}
-	kafkaConnectAcl := resourceAsKafkaConnectAcl(d).(client.KafkaConnectAcl)
 
-	foundAcls, err := kafkaClient.ListAcls(kafkaConnectAcl.Principal)
-	if err != nil {
+	if err := funcReadAcls(kafkaClient, d, resourceAsKafkaConnectAcl, builder.KafkaConnectAclsBuilder); err != nil {
 		return diag.FromErr(err)
 	}
 
-	funcSelectAclsFor(d, foundAcls, kafkaConnectAcl, builder.KafkaConnectAclShouldContinue, builder.KafkaConnectAclsParser)
-
 	return nil
 }
 
+func resourceKafkaConnectUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	kafkaClient := m.(*client.KafkaCluster)
+	builder := client.KafkaAclsBuilder{
+		Client: kafkaClient,
+	}
+
+	log.Printf("[DEBUG] Updating Kafka Connect ACL(s) for %s", d.Id())
+
+	aclInterface, err := funcUpdateAcl(kafkaClient, d, resourceAsKafkaConnectAcl, builder.KafkaConnectAclsBuilder)
+	if err != nil {
+		return aclDiagnostics(err)
+	}
+	acl := aclInterface.(client.KafkaConnectAcl)
+	d.SetId(acl.Id)
+
+	return resourceKafkaConnectRead(ctx, d, m)
+}
+
 func resourceKafkaConnectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -139,3 +153,89 @@ func resourceKafkaConnectDelete(ctx context.Context, d *schema.ResourceData, m i
 
 	return diags
 }
+
+// connectControlTopicKeys lists the control topic attributes in the order they are given in an import id.
+var connectControlTopicKeys = []string{"offset_topic", "status_topic", "configs_topic"}
+
+// resourceKafkaConnectImport accepts an id with the format group#principal, or
+// group#principal#offset_topic,status_topic,configs_topic when the control topics are not the default
+// connect-offsets, connect-status and connect-configs. The other literal topic ACLs of the principal become
+// the read and write topics.
+func resourceKafkaConnectImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "#", 3)
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected group#principal or group#principal#offset_topic,status_topic,configs_topic", d.Id())
+	}
+	group, principal := parts[0], parts[1]
+
+	controlTopics := []string{"connect-offsets", "connect-status", "connect-configs"}
+	if len(parts) == 3 {
+		controlTopics = strings.Split(parts[2], ",")
+		if len(controlTopics) != len(connectControlTopicKeys) {
+			return nil, fmt.Errorf("unexpected format of ID (%s), expected the control topics as offset_topic,status_topic,configs_topic", d.Id())
+		}
+	}
+
+	c := m.(*client.KafkaCluster)
+	foundAcls, err := c.ListAcls(principal)
+	if err != nil {
+		return nil, err
+	}
+
+	operations, topics := literalTopicOperations(foundAcls, principal)
+	isControlTopic := make(map[string]bool)
+	for _, topic := range controlTopics {
+		if !operations[topic][sarama.AclOperationRead] || !operations[topic][sarama.AclOperationWrite] {
+			return nil, fmt.Errorf("control topic %s cannot be read and written by principal %s", topic, principal)
+		}
+		isControlTopic[topic] = true
+	}
+
+	readTopics := make([]string, 0)
+	writeTopics := make([]string, 0)
+	for _, topic := range topics {
+		if isControlTopic[topic] {
+			continue
+		}
+		if operations[topic][sarama.AclOperationRead] {
+			readTopics = append(readTopics, topic)
+		}
+		if operations[topic][sarama.AclOperationWrite] {
+			writeTopics = append(writeTopics, topic)
+		}
+	}
+
+	enableTopicCreate := false
+	for _, entity := range foundAcls {
+		if !c.IsAClusterAcl(entity) {
+			continue
+		}
+		for _, acl := range entity.Acls {
+			if acl.Principal == principal && acl.Operation == sarama.AclOperationCreate {
+				enableTopicCreate = true
+			}
+		}
+	}
+
+	d.Set("group", group)
+	d.Set("principal", principal)
+	d.Set("read_topics", readTopics)
+	d.Set("write_topics", writeTopics)
+	for i, key := range connectControlTopicKeys {
+		d.Set(key, controlTopics[i])
+	}
+	d.Set("enable_topic_create", enableTopicCreate)
+	builder := client.KafkaAclsBuilder{Client: c}
+	if err := importAclRestrictions(d, foundAcls, principal, resourceAsKafkaConnectAcl, builder.KafkaConnectAclsBuilder); err != nil {
+		return nil, err
+	}
+
+	if err := funcVerifyImportedAcls(c, d, resourceAsKafkaConnectAcl, builder.KafkaConnectAclsBuilder); err != nil {
+		return nil, err
+	}
+
+	acl := resourceAsKafkaConnectAcl(d).(client.KafkaConnectAcl)
+	d.SetId(acl.Id)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -30,10 +30,59 @@ func TestAccKafkaConnectAclCreate(t *testing.T) {
 				),
 				ExpectNonEmptyPlan: false,
 			},
+			{
+				ResourceName:            "julieops_kafka_connect_acl.connect",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata"},
+			},
 		},
 	})
 }
 
+func TestAccKafkaConnectAclImportControlTopics(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaConnectAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), testKafkaConnectResourceAcl_controlTopics),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaConnectAclExist("julieops_kafka_connect_acl.connect", "User:connect"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:            "julieops_kafka_connect_acl.connect",
+				ImportState:             true,
+				ImportStateId:           "cluster-a#User:connect#a.offsets,a.status,a.configs",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata"},
+			},
+		},
+	})
+}
+
+// testKafkaConnectResourceAcl_controlTopics uses a user topic named like a control topic, read and written.
+const testKafkaConnectResourceAcl_controlTopics = `
+resource "julieops_kafka_connect_acl" "connect" {
+  principal = "User:connect"
+  group = "cluster-a"
+  read_topics = [ "configs-audit" ]
+  write_topics = [ "configs-audit" ]
+  offset_topic = "a.offsets"
+  status_topic = "a.status"
+  configs_topic = "a.configs"
+}
+`
+
 const testKafkaConnectResourceAcl_noConfig = `
 resource "julieops_kafka_connect_acl" "connect" {
   principal = "%s"
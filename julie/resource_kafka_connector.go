@@ -2,20 +2,33 @@ package julie
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
+	"strings"
 	"terraform-provider-julieops/julie/client"
+	"time"
 )
 
 func resourceKafkaConnector() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceKafkaConnectorCreate,
 		ReadContext:   resourceKafkaConnectorRead,
+		UpdateContext: resourceKafkaConnectorUpdate,
 		DeleteContext: resourceKafkaConnectorDelete,
+		CustomizeDiff: resourceKafkaConnectorCustomizeDiff,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(5 * time.Minute),
+			Update: schema.DefaultTimeout(5 * time.Minute),
+		},
 		Schema: map[string]*schema.Schema{
 			"name": {
 				Type:        schema.TypeString,
@@ -26,9 +39,90 @@ func resourceKafkaConnector() *schema.Resource {
 			"config": {
 				Type:        schema.TypeMap,
 				Required:    true,
-				ForceNew:    true,
 				Description: "A map of string k/v attributes.",
-				Elem:        schema.TypeString,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"config_sensitive": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Sensitive:   true,
+				Description: "A map of string k/v attributes holding secrets, merged with config when writing the connector. On import, the entries Kafka Connect masks or named as secrets, such as connection.password, are read into it.",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"state": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "running",
+				ValidateFunc: validation.StringInSlice([]string{"running", "paused", "stopped"}, false),
+				Description:  "The desired state of the connector, running, paused or stopped. Stopping requires Kafka Connect 3.5 or newer.",
+			},
+			"initial_offsets": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "Offsets written, with the connector stopped, when the connector is created or these offsets change. Requires Kafka Connect 3.6 or newer.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"partition": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsJSON,
+							Description:  "The JSON encoded source partition, or kafka_topic and kafka_partition for sink connectors.",
+						},
+						"offset": {
+							Type:         schema.TypeString,
+							Required:     true,
+							ValidateFunc: validation.StringIsJSON,
+							Description:  "The JSON encoded source offset, or kafka_offset for sink connectors.",
+						},
+					},
+				},
+			},
+			"reset_offsets_trigger": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "Changing this value to a non-empty one resets all the offsets of the connector, with the connector stopped, before any initial_offsets are written. Requires Kafka Connect 3.6 or newer.",
+			},
+			"wait_for_running": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Wait, within the create and update timeouts, until the connector and all its tasks are RUNNING. Only applies to running connectors.",
+			},
+			"restart_on_failure": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Restart the failed connector and tasks on refresh and apply, reporting them in a warning.",
+			},
+			"connector_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the connector.",
+			},
+			"tasks": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The state of the connector tasks.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:     schema.TypeInt,
+							Computed: true,
+						},
+						"state": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+						"worker_id": {
+							Type:     schema.TypeString,
+							Computed: true,
+						},
+					},
+				},
 			},
 		},
 	}
@@ -39,12 +133,28 @@ func resourceKafkaConnectorCreate(ctx context.Context, d *schema.ResourceData, m
 
 	connectorData := extractConnectorResource(d)
 
+	if diags := connectorConfigDiagnostics(c.KafkaConnectClient, connectorData.Name, connectorData.Config); diags.HasError() {
+		return diags
+	}
+
+	offsets, err := resourceAsConnectorOffsets(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	var request = client.ConnectorCreateRequest{
 		Name:   connectorData.Name,
 		Config: connectorData.Config,
 	}
 
-	response, err := c.KafkaConnectClient.AddOrUpdateConnector(request)
+	var response *client.ConnectorCreateResponse
+	if len(offsets.Offsets) > 0 {
+		// the connector must not process any record before its offsets are written
+		request.InitialState = "STOPPED"
+		response, err = c.KafkaConnectClient.CreateConnector(request)
+	} else {
+		response, err = c.KafkaConnectClient.AddOrUpdateConnector(request)
+	}
 
 	if err != nil {
 		return diag.FromErr(err)
@@ -54,7 +164,26 @@ func resourceKafkaConnectorCreate(ctx context.Context, d *schema.ResourceData, m
 
 	d.SetId(response.Name)
 
-	return nil
+	if len(offsets.Offsets) > 0 {
+		if err := writeConnectorOffsets(ctx, c.KafkaConnectClient, response.Name, offsets, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	state := d.Get("state").(string)
+	if state != "running" || len(offsets.Offsets) > 0 {
+		if err := applyConnectorState(ctx, c.KafkaConnectClient, response.Name, state, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if state == "running" && d.Get("wait_for_running").(bool) {
+		if err := waitForConnectorRunning(ctx, c.KafkaConnectClient, response.Name, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKafkaConnectorRead(ctx, d, m)
 }
 
 func resourceKafkaConnectorRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -63,21 +192,129 @@ func resourceKafkaConnectorRead(ctx context.Context, d *schema.ResourceData, m i
 	name := d.Id()
 
 	response, err := c.KafkaConnectClient.GetConnector(name)
+	if errors.Is(err, client.ErrConnectorNotFound) {
+		log.Printf("[WARN] connector %s not found, removing it from state", name)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
+	log.Printf("[DEBUG] reading the information about connector %s", name)
+
+	status, err := c.KafkaConnectClient.GetConnectorStatus(name)
+	if errors.Is(err, client.ErrConnectorNotFound) {
+		log.Printf("[WARN] connector %s not found, removing it from state", name)
+		d.SetId("")
+		return nil
+	}
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
-	delete(response.Config, "name") // remove the name parameter from config as it causes conflicts
+	var diags diag.Diagnostics
+	if d.Get("restart_on_failure").(bool) {
+		restarted, err := restartFailedConnector(c.KafkaConnectClient, *status)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if len(restarted) > 0 {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Restarted failed instances of connector %s", name),
+				Detail:   fmt.Sprintf("Restarted %s", strings.Join(restarted, ", ")),
+			})
+			if status, err = c.KafkaConnectClient.GetConnectorStatus(name); err != nil {
+				return diag.FromErr(err)
+			}
+		}
+	}
 
-	log.Printf("[DEBUG] reading the information about connector %s", name)
-	log.Printf("[DEBUG] read config -> %v", response.Config)
+	tasks := make([]interface{}, len(status.Tasks))
+	for i, task := range status.Tasks {
+		tasks[i] = map[string]interface{}{
+			"id":        task.Id,
+			"state":     task.State,
+			"worker_id": task.WorkerId,
+		}
+	}
 
 	d.Set("name", response.Name)
-	d.Set("config", response.Config)
+	config, sensitiveConfig := splitConnectorConfig(d, response.Config)
+	d.Set("config", config)
+	d.Set("config_sensitive", sensitiveConfig)
+	d.Set("connector_state", status.Connector.State)
+	// transient states such as UNASSIGNED or FAILED are not a desired state, only connector_state reflects them
+	switch status.Connector.State {
+	case "RUNNING", "PAUSED", "STOPPED":
+		d.Set("state", strings.ToLower(status.Connector.State))
+	}
+	d.Set("tasks", tasks)
 	d.SetId(response.Name)
 
-	return nil
+	return diags
+}
+
+func resourceKafkaConnectorUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	c := m.(*client.KafkaCluster)
+
+	connectorData := extractConnectorResource(d)
+
+	// a config PUT restarts the connector and its tasks, it is only sent when the config changes
+	if d.HasChanges("config", "config_sensitive") {
+		if diags := connectorConfigDiagnostics(c.KafkaConnectClient, connectorData.Name, connectorData.Config); diags.HasError() {
+			return diags
+		}
+
+		var request = client.ConnectorCreateRequest{
+			Name:   connectorData.Name,
+			Config: connectorData.Config,
+		}
+
+		// the config PUT is an upsert, the connector keeps running with its offsets
+		response, err := c.KafkaConnectClient.AddOrUpdateConnector(request)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		log.Printf("[DEBUG] resource Kafka Connector Update connector with name %s and Tasks %v", connectorData.Name, response.Tasks)
+	}
+
+	offsets, err := resourceAsConnectorOffsets(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	offsetsReset := d.HasChange("reset_offsets_trigger") && d.Get("reset_offsets_trigger").(string) != ""
+	if offsetsReset {
+		if err := resetConnectorOffsets(ctx, c.KafkaConnectClient, connectorData.Name, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	offsetsChanged := d.HasChange("initial_offsets") && len(offsets.Offsets) > 0
+	if offsetsChanged {
+		if err := writeConnectorOffsets(ctx, c.KafkaConnectClient, connectorData.Name, offsets, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	state := d.Get("state").(string)
+	if d.HasChange("state") || offsetsReset || offsetsChanged {
+		if err := applyConnectorState(ctx, c.KafkaConnectClient, connectorData.Name, state, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if state == "running" && d.Get("wait_for_running").(bool) {
+		if err := waitForConnectorRunning(ctx, c.KafkaConnectClient, connectorData.Name, d.Timeout(schema.TimeoutUpdate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceKafkaConnectorRead(ctx, d, m)
 }
 
 func resourceKafkaConnectorDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -95,3 +332,197 @@ func resourceKafkaConnectorDelete(ctx context.Context, d *schema.ResourceData, m
 
 	return diags
 }
+
+// resourceKafkaConnectorCustomizeDiff fails the plan when the connector plugin is not installed or the Connect
+// cluster rejects the connector config.
+func resourceKafkaConnectorCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.NewValueKnown("name") || !d.NewValueKnown("config") || !d.NewValueKnown("config_sensitive") {
+		return nil
+	}
+	if d.Id() != "" && !d.HasChange("config") && !d.HasChange("config_sensitive") {
+		return nil
+	}
+
+	c := m.(*client.KafkaCluster)
+	connectorData := extractConnectorResource(d)
+	name := connectorData.Name
+
+	diags := connectorConfigDiagnostics(c.KafkaConnectClient, name, connectorData.Config)
+	if !diags.HasError() {
+		return nil
+	}
+
+	// CustomizeDiff only reports errors, the attribute of each diagnostic is kept in its message
+	messages := make([]string, 0)
+	for _, diagnostic := range diags {
+		messages = append(messages, diagnostic.Detail)
+	}
+	return fmt.Errorf("invalid config for connector %s:\n%s", name, strings.Join(messages, "\n"))
+}
+
+// connectorConfigDiagnostics checks the connector plugin is installed and validates the config against it, every
+// invalid config key is reported as a diagnostic on its config attribute.
+func connectorConfigDiagnostics(kc client.KafkaConnectCluster, name string, config map[string]interface{}) diag.Diagnostics {
+	configPath := cty.GetAttrPath("config")
+
+	class, _ := config["connector.class"].(string)
+	if class == "" {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Missing connector class",
+			Detail:        "config[\"connector.class\"]: the connector.class config is required",
+			AttributePath: configPath,
+		}}
+	}
+
+	plugins, err := kc.GetConnectorPlugins()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	if !isPluginInstalled(plugins, class) {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Connector plugin not installed",
+			Detail:        fmt.Sprintf("config[\"connector.class\"]: the plugin %s is not installed in the Kafka Connect cluster", class),
+			AttributePath: configPath.IndexString("connector.class"),
+		}}
+	}
+
+	validationConfig := make(map[string]interface{}, len(config)+1)
+	for k, v := range config {
+		validationConfig[k] = v
+	}
+	validationConfig["name"] = name
+
+	response, err := kc.ValidateConnectorConfig(class, validationConfig)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, result := range response.Configs {
+		if len(result.Value.Errors) == 0 {
+			continue
+		}
+		log.Printf("[DEBUG] connector %s config %s is invalid: %v", name, result.Value.Name, result.Value.Errors)
+		diags = append(diags, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid connector config",
+			Detail:        fmt.Sprintf("config[\"%s\"]: %s", result.Value.Name, strings.Join(result.Value.Errors, "; ")),
+			AttributePath: configPath.IndexString(result.Value.Name),
+		})
+	}
+	return diags
+}
+
+// isPluginInstalled matches a connector class by its fully qualified or simple name, as Connect accepts both.
+func isPluginInstalled(plugins []client.ConnectorPlugin, class string) bool {
+	for _, plugin := range plugins {
+		if plugin.Class == class || strings.HasSuffix(plugin.Class, "."+class) {
+			return true
+		}
+	}
+	return false
+}
+
+// waitForConnectorRunning polls the connector status until the connector and all its tasks are RUNNING, failing
+// with the trace of the first FAILED task or connector.
+func waitForConnectorRunning(ctx context.Context, kc client.KafkaConnectCluster, name string, timeout time.Duration) error {
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		status, err := kc.GetConnectorStatus(name)
+		if err != nil {
+			return resource.RetryableError(err)
+		}
+
+		if status.Connector.State == "FAILED" {
+			return resource.NonRetryableError(fmt.Errorf("connector %s failed: %s", name, status.Connector.Trace))
+		}
+		if failed := status.FailedTasks(); len(failed) > 0 {
+			return resource.NonRetryableError(fmt.Errorf("task %d of connector %s failed: %s", failed[0].Id, name, failed[0].Trace))
+		}
+		if !status.IsRunning() {
+			log.Printf("[DEBUG] waiting for connector %s to be running, state %s", name, status.Connector.State)
+			return resource.RetryableError(fmt.Errorf("connector %s is not running yet, state %s", name, status.Connector.State))
+		}
+
+		return nil
+	})
+}
+
+// applyConnectorState pauses, stops or resumes a connector and waits until the connector reaches that state.
+func applyConnectorState(ctx context.Context, kc client.KafkaConnectCluster, name string, state string, timeout time.Duration) error {
+	log.Printf("[DEBUG] changing the state of connector %s to %s", name, state)
+
+	var err error
+	switch state {
+	case "paused":
+		err = kc.PauseConnector(name)
+	case "stopped":
+		err = kc.StopConnector(name)
+	default:
+		err = kc.ResumeConnector(name)
+	}
+	if err != nil {
+		return err
+	}
+
+	target := strings.ToUpper(state)
+	return resource.RetryContext(ctx, timeout, func() *resource.RetryError {
+		status, err := kc.GetConnectorStatus(name)
+		if err != nil {
+			return resource.RetryableError(err)
+		}
+		if status.Connector.State != target {
+			return resource.RetryableError(fmt.Errorf("connector %s is not %s yet, state %s", name, state, status.Connector.State))
+		}
+		return nil
+	})
+}
+
+// restartFailedConnector restarts the failed connector, with its failed tasks, or else each failed task, returning a
+// description of every restarted instance.
+func restartFailedConnector(kc client.KafkaConnectCluster, status client.ConnectorStatusResponse) ([]string, error) {
+	restarted := make([]string, 0)
+	failedTasks := status.FailedTasks()
+
+	if status.Connector.State == "FAILED" {
+		log.Printf("[DEBUG] restarting the failed connector %s and its failed tasks", status.Name)
+		if err := kc.RestartConnector(status.Name, true, true); err != nil {
+			return nil, err
+		}
+		restarted = append(restarted, "connector "+status.Name)
+		for _, task := range failedTasks {
+			restarted = append(restarted, fmt.Sprintf("task %d", task.Id))
+		}
+		return restarted, nil
+	}
+
+	for _, task := range failedTasks {
+		log.Printf("[DEBUG] restarting the failed task %d of connector %s", task.Id, status.Name)
+		if err := kc.RestartTask(status.Name, task.Id); err != nil {
+			return nil, err
+		}
+		restarted = append(restarted, fmt.Sprintf("task %d", task.Id))
+	}
+	return restarted, nil
+}
+
+// writeConnectorOffsets stops the connector and writes its offsets, leaving the connector stopped.
+func writeConnectorOffsets(ctx context.Context, kc client.KafkaConnectCluster, name string, offsets client.ConnectorOffsets, timeout time.Duration) error {
+	if err := applyConnectorState(ctx, kc, name, "stopped", timeout); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] writing %d offsets of connector %s", len(offsets.Offsets), name)
+	return kc.AlterConnectorOffsets(name, offsets)
+}
+
+// resetConnectorOffsets stops the connector and removes all its offsets, leaving the connector stopped.
+func resetConnectorOffsets(ctx context.Context, kc client.KafkaConnectCluster, name string, timeout time.Duration) error {
+	if err := applyConnectorState(ctx, kc, name, "stopped", timeout); err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] resetting the offsets of connector %s", name)
+	return kc.ResetConnectorOffsets(name)
+}
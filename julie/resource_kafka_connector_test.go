@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
 	"terraform-provider-julieops/julie/client"
 	julieTest "terraform-provider-julieops/julie/test"
 	"testing"
+	"time"
 )
 
 func TestAccKafkaConnectCreate(t *testing.T) {
@@ -26,9 +28,319 @@ func TestAccKafkaConnectCreate(t *testing.T) {
 		CheckDestroy: testAccKafkaConnectorDelete,
 		Steps: []resource.TestStep{
 			{
-				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector, connectorName)),
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector, connectorName, "1")),
 				Check: resource.ComposeTestCheckFunc(
 					testAccKafkaConnectorExist("julieops_kafka_connector.test"),
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "config.max.interval", "100"),
+					resource.TestCheckNoResourceAttr("julieops_kafka_connector.test", "config.name"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:            "julieops_kafka_connector.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_running", "restart_on_failure"},
+			},
+			{
+				PreConfig: func() {
+					c := testProvider.Meta().(*client.KafkaCluster)
+					c.KafkaConnectClient.DeleteConnector(connectorName)
+				},
+				Config:             cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector, connectorName, "1")),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccKafkaConnectUpdate(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true, EnableKafkaConnect: true,
+	}, t)
+	defer close(ctx)
+
+	connectorName := "foo"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaConnectorDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector, connectorName, "1")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaConnectorExist("julieops_kafka_connector.test"),
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "config.tasks.max", "1"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector, connectorName, "2")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaConnectorExist("julieops_kafka_connector.test"),
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "config.tasks.max", "2"),
+					testAccKafkaConnectorTasksMax("foo", "2"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func TestAccKafkaConnectInvalidConfig(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true, EnableKafkaConnect: true,
+	}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaConnectorDelete,
+		Steps: []resource.TestStep{
+			{
+				Config:      cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector, "foo", "not-a-number")),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`config\["tasks.max"\]`),
+			},
+			{
+				Config:      cfg(setup.AkContainer.URI, setup.KcContainer.URI, testResourceConnector_unknownPlugin),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("the plugin com.example.MissingConnector is not installed"),
+			},
+		},
+	})
+}
+
+func TestAccKafkaConnectWaitForRunning(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true, EnableKafkaConnect: true,
+	}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaConnectorDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, testResourceConnector_waitForRunning),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaConnectorExist("julieops_kafka_connector.test"),
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "connector_state", "RUNNING"),
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "tasks.#", "1"),
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "tasks.0.state", "RUNNING"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testResourceConnector_waitForRunning = `
+resource "julieops_kafka_connector" "test" {
+  name = "foo"
+  config = {
+    "connector.class" =                 "io.confluent.kafka.connect.datagen.DatagenConnector"
+    "kafka.topic" =                     "pageviews"
+    "quickstart" =                      "pageviews"
+    "key.converter" =                   "org.apache.kafka.connect.storage.StringConverter"
+    "value.converter" =                 "org.apache.kafka.connect.json.JsonConverter"
+    "value.converter.schemas.enable" =  "false"
+    "tasks.max" =                       "1"
+  }
+  wait_for_running = true
+  timeouts {
+    create = "2m"
+  }
+}
+`
+
+func TestAccKafkaConnectState(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true, EnableKafkaConnect: true,
+	}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaConnectorDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector_state, "running")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "state", "running"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				PreConfig: func() {
+					c := testProvider.Meta().(*client.KafkaCluster)
+					applyConnectorState(ctx, c.KafkaConnectClient, "foo", "paused", time.Minute)
+				},
+				Config:             cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector_state, "running")),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector_state, "paused")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "state", "paused"),
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "connector_state", "PAUSED"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector_state, "running")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "connector_state", "RUNNING"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testResourceConnector_state = `
+resource "julieops_kafka_connector" "test" {
+  name = "foo"
+  config = {
+    "connector.class" =                 "io.confluent.kafka.connect.datagen.DatagenConnector"
+    "kafka.topic" =                     "pageviews"
+    "quickstart" =                      "pageviews"
+    "key.converter" =                   "org.apache.kafka.connect.storage.StringConverter"
+    "value.converter" =                 "org.apache.kafka.connect.json.JsonConverter"
+    "value.converter.schemas.enable" =  "false"
+    "tasks.max" =                       "1"
+  }
+  state = "%s"
+}
+`
+
+func TestAccKafkaConnectStateKeepsConfig(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true, EnableKafkaConnect: true,
+	}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaConnectorDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector_state, "running")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "connector_state", "RUNNING"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				// an unmanaged key written out of band is only lost if the config is PUT again, restarting the tasks
+				PreConfig: func() {
+					c := testProvider.Meta().(*client.KafkaCluster)
+					if err := testAccKafkaConnectorAddConfig(c.KafkaConnectClient, "foo", "custom.marker", "kept"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector_state, "paused")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "connector_state", "PAUSED"),
+					testAccKafkaConnectorConfigValue("foo", "custom.marker", "kept"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector_state, "running")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "connector_state", "RUNNING"),
+					testAccKafkaConnectorConfigValue("foo", "custom.marker", "kept"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func testAccKafkaConnectorAddConfig(kc client.KafkaConnectCluster, name string, key string, value string) error {
+	connector, err := kc.GetConnector(name)
+	if err != nil {
+		return err
+	}
+
+	config := make(map[string]interface{}, len(connector.Config)+1)
+	for k, v := range connector.Config {
+		config[k] = v
+	}
+	config[key] = value
+
+	_, err = kc.AddOrUpdateConnector(client.ConnectorCreateRequest{Name: name, Config: config})
+	return err
+}
+
+func testAccKafkaConnectorConfigValue(name string, key string, value string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := testProvider.Meta().(*client.KafkaCluster)
+		connector, err := c.KafkaConnectClient.GetConnector(name)
+		if err != nil {
+			return err
+		}
+
+		if connector.Config[key] != value {
+			return fmt.Errorf("connector %s with unexpected %s %q, expected %q", name, key, connector.Config[key], value)
+		}
+
+		return nil
+	}
+}
+
+func TestAccKafkaConnectRestartOnFailure(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true, EnableKafkaConnect: true,
+	}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaConnectorDelete,
+		Steps: []resource.TestStep{
+			{
+				Config:             cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector_failing, false)),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				// the task fails converting its first record, then is restarted by the refresh that follows the apply
+				PreConfig: func() {
+					c := testProvider.Meta().(*client.KafkaCluster)
+					if err := testAccKafkaConnectorWaitForTaskState(c.KafkaConnectClient, "foo", "FAILED"); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector_failing, true)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "connector_state", "RUNNING"),
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "tasks.0.state", "RUNNING"),
 				),
 				ExpectNonEmptyPlan: false,
 			},
@@ -36,6 +348,164 @@ func TestAccKafkaConnectCreate(t *testing.T) {
 	})
 }
 
+// testResourceConnector_failing converts its records with a schema registry nobody listens to, its task fails on the
+// first record, within max.interval milliseconds from its start.
+const testResourceConnector_failing = `
+resource "julieops_kafka_connector" "test" {
+  name = "foo"
+  config = {
+    "connector.class" =                     "io.confluent.kafka.connect.datagen.DatagenConnector"
+    "kafka.topic" =                         "pageviews"
+    "quickstart" =                          "pageviews"
+    "key.converter" =                       "org.apache.kafka.connect.storage.StringConverter"
+    "value.converter" =                     "io.confluent.connect.avro.AvroConverter"
+    "value.converter.schema.registry.url" = "http://localhost:1"
+    "max.interval" =                        "10000"
+    "tasks.max" =                           "1"
+  }
+  restart_on_failure = %t
+}
+`
+
+func testAccKafkaConnectorWaitForTaskState(kc client.KafkaConnectCluster, name string, state string) error {
+	return resource.Retry(2*time.Minute, func() *resource.RetryError {
+		status, err := kc.GetConnectorStatus(name)
+		if err != nil {
+			return resource.NonRetryableError(err)
+		}
+		if len(status.Tasks) == 0 || status.Tasks[0].State != state {
+			return resource.RetryableError(fmt.Errorf("task 0 of connector %s is not %s yet", name, state))
+		}
+		return nil
+	})
+}
+
+func TestAccKafkaConnectSensitiveConfig(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true, EnableKafkaConnect: true,
+	}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaConnectorDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, testResourceConnector_sensitiveConfig),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "config_sensitive.custom.secret", "s3cr3t"),
+					resource.TestCheckNoResourceAttr("julieops_kafka_connector.test", "config.custom.secret"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:            "julieops_kafka_connector.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"wait_for_running", "restart_on_failure"},
+			},
+		},
+	})
+}
+
+const testResourceConnector_sensitiveConfig = `
+resource "julieops_kafka_connector" "test" {
+  name = "foo"
+  config = {
+    "connector.class" =                 "io.confluent.kafka.connect.datagen.DatagenConnector"
+    "kafka.topic" =                     "pageviews"
+    "quickstart" =                      "pageviews"
+    "tasks.max" =                       "1"
+  }
+  config_sensitive = {
+    "custom.secret" = "s3cr3t"
+  }
+}
+`
+
+func TestAccKafkaConnectResetOffsets(t *testing.T) {
+	skipIfPlatformOlderThan(t, 7, 6)
+
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{
+		EnableSchemaRegistry: true, EnableKafkaConnect: true,
+	}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaConnectorDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector_resetOffsets, "")),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaConnectorOffsetsCount("foo", 1),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: cfg(setup.AkContainer.URI, setup.KcContainer.URI, fmt.Sprintf(testResourceConnector_resetOffsets, "migration-1")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_connector.test", "state", "stopped"),
+					testAccKafkaConnectorOffsetsCount("foo", 0),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+const testResourceConnector_resetOffsets = `
+resource "julieops_kafka_connector" "test" {
+  name = "foo"
+  config = {
+    "connector.class" = "io.confluent.kafka.connect.datagen.DatagenConnector"
+    "kafka.topic" =     "pageviews"
+    "quickstart" =      "pageviews"
+    "tasks.max" =       "1"
+  }
+  state = "stopped"
+  initial_offsets {
+    partition = jsonencode({ "task.id" = 0 })
+    offset    = jsonencode({ "current.iteration" = 100 })
+  }
+  reset_offsets_trigger = "%s"
+}
+`
+
+func testAccKafkaConnectorOffsetsCount(name string, count int) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := testProvider.Meta().(*client.KafkaCluster)
+		offsets, err := c.KafkaConnectClient.GetConnectorOffsets(name)
+		if err != nil {
+			return err
+		}
+
+		if len(offsets.Offsets) != count {
+			return fmt.Errorf("connector %s with %d offsets, expected %d", name, len(offsets.Offsets), count)
+		}
+
+		return nil
+	}
+}
+
+const testResourceConnector_unknownPlugin = `
+resource "julieops_kafka_connector" "test" {
+  name = "foo"
+  config = {
+    "connector.class" = "com.example.MissingConnector"
+    "tasks.max" =       "1"
+  }
+}
+`
+
 const testResourceConnector = `
 resource "julieops_kafka_connector" "test" {
   name = "%s"
@@ -48,7 +518,7 @@ resource "julieops_kafka_connector" "test" {
     "value.converter.schemas.enable" =  "false"
     "max.interval" =                    100
     "iterations" =                      10000000
-    "tasks.max" =                       "1"
+    "tasks.max" =                       "%s"
   }
 }
 `
@@ -84,3 +554,19 @@ func testAccKafkaConnectorExist(name string) resource.TestCheckFunc {
 		return nil
 	}
 }
+
+func testAccKafkaConnectorTasksMax(name string, tasksMax string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		c := testProvider.Meta().(*client.KafkaCluster)
+		connector, err := c.KafkaConnectClient.GetConnector(name)
+		if err != nil {
+			return err
+		}
+
+		if connector.Config["tasks.max"] != tasksMax {
+			return fmt.Errorf("connector %s with unexpected tasks.max %s", name, connector.Config["tasks.max"])
+		}
+
+		return nil
+	}
+}
@@ -2,9 +2,14 @@ package julie
 
 import (
 	"context"
+	"fmt"
+	"github.com/Shopify/sarama"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
+	"sort"
+	"strings"
 	"terraform-provider-julieops/julie/client"
 )
 
@@ -12,16 +17,37 @@ func resourceKafkaConsumerAcl() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceKafkaConsumerCreate,
 		ReadContext:   resourceKafkaConsumerRead,
+		UpdateContext: resourceKafkaConsumerUpdate,
 		DeleteContext: resourceKafkaConsumerDelete,
+		CustomizeDiff: funcAclsCustomizeDiff(resourceAsConsumerAcl, client.KafkaAclsBuilder{}.ConsumerAclsBuilder),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceKafkaConsumerImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"project": {
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Description: "The project prefix used to build the resource ACLs",
+				Type:          schema.TypeString,
+				Optional:      true,
+				ConflictsWith: []string{"topics", "topic_prefixes"},
+				AtLeastOneOf:  []string{"project", "topics", "topic_prefixes"},
+				Description:   "The project prefix used to build the resource ACLs",
+			},
+			"topics": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ConflictsWith: []string{"project"},
+				Description:   "The topics the consumer is allowed to read, as an alternative to the project prefix",
+			},
+			"topic_prefixes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				ConflictsWith: []string{"project"},
+				Description:   "The topic prefixes the consumer is allowed to read, as an alternative to the project prefix",
 			},
 			"principal": {
 				Type:        schema.TypeString,
@@ -32,17 +58,39 @@ func resourceKafkaConsumerAcl() *schema.Resource {
 			"group": {
 				Type:        schema.TypeString,
 				Optional:    true,
-				ForceNew:    true,
 				Default:     "*",
-				Description: "The consumer group name.",
+				Description: "The consumer group name. Ignored when groups are set.",
+			},
+			"groups": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The consumer groups the principal is allowed to use",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The consumer group name or prefix",
+						},
+						"pattern_type": {
+							Type:         schema.TypeString,
+							Optional:     true,
+							Default:      "Literal",
+							ValidateFunc: validation.StringInSlice([]string{"Literal", "Prefixed"}, true),
+							Description:  "The group pattern type, Literal or Prefixed",
+						},
+					},
+				},
 			},
 			"metadata": {
 				Type:        schema.TypeMap,
 				Optional:    true,
-				ForceNew:    true,
 				Description: "Map of optional values describing metadata information for this consumer",
 				Elem:        schema.TypeString,
 			},
+			"hosts":        hostsSchema(),
+			"deny":         denySchema(),
+			"acl_bindings": aclBindingsSchema(),
 		},
 	}
 }
@@ -53,9 +101,9 @@ func resourceKafkaConsumerCreate(ctx context.Context, d *schema.ResourceData, m
 		Client: kafkaClient,
 	}
 
-	aclInterface, err := funcCreateAcl(kafkaClient, builder, d, resourceAsConsumerAcl, builder.ConsumerAclsBuilder)
+	aclInterface, err := funcCreateAclBundle(kafkaClient, builder, d, resourceAsConsumerAcl, builder.ConsumerAclsBuilder)
 	if err != nil {
-		return diag.FromErr(err)
+		return aclDiagnostics(err)
 	}
 	acl := aclInterface.(client.ConsumerAcl)
 	d.SetId(acl.Id)
@@ -69,18 +117,32 @@ func resourceKafkaConsumerRead(ctx context.Context, d *schema.ResourceData, m in
 	builder := client.KafkaAclsBuilder{
 		Client: kafkaClient,
 	}
-	consumerAcl := resourceAsConsumerAcl(d).(client.ConsumerAcl)
 
-	foundAcls, err := kafkaClient.ListAcls(consumerAcl.Principal)
-	if err != nil {
+	if err := funcReadAcls(kafkaClient, d, resourceAsConsumerAcl, builder.ConsumerAclsBuilder); err != nil {
 		return diag.FromErr(err)
 	}
 
-	funcSelectAclsFor(d, foundAcls, consumerAcl, builder.ConsumerAclShouldContinue, builder.ConsumerAclsParser)
-
 	return nil
 }
 
+func resourceKafkaConsumerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	kafkaClient := m.(*client.KafkaCluster)
+	builder := client.KafkaAclsBuilder{
+		Client: kafkaClient,
+	}
+
+	log.Printf("[DEBUG] Updating consumer ACL(s) for %s", d.Id())
+
+	aclInterface, err := funcUpdateAcl(kafkaClient, d, resourceAsConsumerAcl, builder.ConsumerAclsBuilder)
+	if err != nil {
+		return aclDiagnostics(err)
+	}
+	acl := aclInterface.(client.ConsumerAcl)
+	d.SetId(acl.Id)
+
+	return resourceKafkaConsumerRead(ctx, d, m)
+}
+
 func resourceKafkaConsumerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	var diags diag.Diagnostics
 
@@ -97,3 +159,148 @@ func resourceKafkaConsumerDelete(ctx context.Context, d *schema.ResourceData, m
 
 	return diags
 }
+
+// resourceKafkaConsumerImport accepts an id with the format project#principal#group, optionally followed by
+// #topic1,topic2 and #prefix1,prefix2 to import the given topics and topic prefixes. An empty project without them
+// imports the topics and topic prefixes the principal consumes, an empty group imports all its consumer groups.
+func resourceKafkaConsumerImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "#")
+	if len(parts) < 3 || len(parts) > 5 || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected project#principal#group or project#principal#group#topic1,topic2#prefix1,prefix2", d.Id())
+	}
+	project, principal, group := parts[0], parts[1], parts[2]
+
+	d.Set("project", project)
+	d.Set("principal", principal)
+	d.Set("group", group)
+
+	c := m.(*client.KafkaCluster)
+	foundAcls, err := c.ListAcls(principal)
+	if err != nil {
+		return nil, err
+	}
+
+	// a consumer is granted Describe and Read on its topics, while streams and connect bundles only grant Read
+	consumedTopics := make([]string, 0)
+	operations, literalTopics := literalTopicOperations(foundAcls, principal)
+	for _, topic := range literalTopics {
+		if operations[topic][sarama.AclOperationRead] && operations[topic][sarama.AclOperationDescribe] {
+			consumedTopics = append(consumedTopics, topic)
+		}
+	}
+	consumedPrefixes := make([]string, 0)
+	for _, prefix := range allowedResources(foundAcls, principal, sarama.AclResourceTopic, sarama.AclPatternPrefixed,
+		sarama.AclOperationRead, sarama.AclOperationDescribe) {
+		if prefix != project {
+			consumedPrefixes = append(consumedPrefixes, prefix)
+		}
+	}
+
+	for i, attribute := range []string{"topics", "topic_prefixes"} {
+		found := [][]string{consumedTopics, consumedPrefixes}[i]
+		switch {
+		case len(parts) > 3+i:
+			names, err := importedResources(found, parts[3+i])
+			if err != nil {
+				return nil, fmt.Errorf("no consumer ACLs found for the %s of principal %s: %w", attribute, principal, err)
+			}
+			d.Set(attribute, names)
+		case project == "" && len(parts) == 3:
+			d.Set(attribute, found)
+		}
+	}
+
+	// the prefixed group of a streams application comes with All on the topics of the same prefix
+	if group == "" {
+		d.Set("group", "*")
+		streamsApplications := make(map[string]bool)
+		for _, name := range allowedResources(foundAcls, principal, sarama.AclResourceTopic, sarama.AclPatternPrefixed, sarama.AclOperationAll) {
+			streamsApplications[name] = true
+		}
+		groups := make([]interface{}, 0)
+		for _, patternType := range []sarama.AclResourcePatternType{sarama.AclPatternLiteral, sarama.AclPatternPrefixed} {
+			for _, name := range readableResources(foundAcls, principal, sarama.AclResourceGroup, patternType) {
+				if patternType == sarama.AclPatternPrefixed && streamsApplications[name] {
+					continue
+				}
+				groups = append(groups, map[string]interface{}{
+					"name":         name,
+					"pattern_type": patternType.String(),
+				})
+			}
+		}
+		d.Set("groups", groups)
+	}
+	d.SetId(resourceAsConsumerAcl(d).(client.ConsumerAcl).Id)
+
+	builder := client.KafkaAclsBuilder{Client: c}
+	if err := importAclRestrictions(d, foundAcls, principal, resourceAsConsumerAcl, builder.ConsumerAclsBuilder); err != nil {
+		return nil, err
+	}
+
+	if err := funcVerifyImportedAcls(c, d, resourceAsConsumerAcl, builder.ConsumerAclsBuilder); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// importedResources checks every name of a comma separated list is among the found ones, returning them sorted.
+func importedResources(found []string, list string) ([]string, error) {
+	isFound := make(map[string]bool, len(found))
+	for _, name := range found {
+		isFound[name] = true
+	}
+
+	names := make([]string, 0)
+	if list == "" {
+		return names, nil
+	}
+	for _, name := range strings.Split(list, ",") {
+		if !isFound[name] {
+			return nil, fmt.Errorf("%s not found", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+// readableResources lists, sorted, the resources of a type and pattern type the principal is allowed to read.
+func readableResources(foundAcls []sarama.ResourceAcls, principal string, resourceType sarama.AclResourceType,
+	patternType sarama.AclResourcePatternType) []string {
+	return allowedResources(foundAcls, principal, resourceType, patternType, sarama.AclOperationRead)
+}
+
+// writableResources lists, sorted, the resources of a type and pattern type the principal is allowed to write.
+func writableResources(foundAcls []sarama.ResourceAcls, principal string, resourceType sarama.AclResourceType,
+	patternType sarama.AclResourcePatternType) []string {
+	return allowedResources(foundAcls, principal, resourceType, patternType, sarama.AclOperationWrite)
+}
+
+// allowedResources lists, sorted, the resources of a type and pattern type the principal is allowed all the given
+// operations on.
+func allowedResources(foundAcls []sarama.ResourceAcls, principal string, resourceType sarama.AclResourceType,
+	patternType sarama.AclResourcePatternType, operations ...sarama.AclOperation) []string {
+	names := make([]string, 0)
+	for _, entity := range foundAcls {
+		if entity.ResourceType != resourceType || entity.ResourcePatternType != patternType {
+			continue
+		}
+		allowed := make(map[sarama.AclOperation]bool)
+		for _, acl := range entity.Acls {
+			if acl.Principal == principal && acl.PermissionType == sarama.AclPermissionAllow {
+				allowed[acl.Operation] = true
+			}
+		}
+		allowsAll := true
+		for _, operation := range operations {
+			allowsAll = allowsAll && allowed[operation]
+		}
+		if allowsAll {
+			names = append(names, entity.ResourceName)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
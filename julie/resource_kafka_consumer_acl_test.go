@@ -3,8 +3,10 @@ package julie
 import (
 	"context"
 	"fmt"
+	"github.com/Shopify/sarama"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"strconv"
 	"terraform-provider-julieops/julie/client"
 	julieTest "terraform-provider-julieops/julie/test"
 	"testing"
@@ -30,10 +32,284 @@ func TestAccKafkaAclCreate(t *testing.T) {
 				),
 				ExpectNonEmptyPlan: false,
 			},
+			{
+				ResourceName:            "julieops_kafka_consumer_acl.consumer",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata"},
+			},
+		},
+	})
+}
+
+func TestAccKafkaAclDriftDetection(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	project := "foo"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testResourceAcl_noConfig, project)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaAclExist("julieops_kafka_consumer_acl.consumer", "User:bar"),
+					resource.TestCheckResourceAttr("julieops_kafka_consumer_acl.consumer", "acl_bindings.#", "3"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				PreConfig: func() {
+					testAccKafkaAclDeleteRead(project, "User:bar")
+				},
+				Config:             cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testResourceAcl_noConfig, project)),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccKafkaAclExtraBindingDrift(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	project := "foo"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config:             cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testResourceAcl_noConfig, project)),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				PreConfig: func() {
+					testAccKafkaAclGrantExtraRead(project, "User:bar", "10.0.0.9")
+				},
+				Config:             cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testResourceAcl_noConfig, project)),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testResourceAcl_noConfig, project)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_consumer_acl.consumer", "acl_bindings.#", "3"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func TestAccKafkaAclHostsAndDeny(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), testResourceAcl_hostsAndDeny),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaAclExist("julieops_kafka_consumer_acl.tenant", "User:tenant"),
+					resource.TestCheckResourceAttr("julieops_kafka_consumer_acl.tenant", "acl_bindings.#", "8"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      "julieops_kafka_consumer_acl.tenant",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKafkaAclTopicsAndGroups(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), testResourceAcl_topicsAndGroups),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaAclExist("julieops_kafka_consumer_acl.reader", "User:reader"),
+					resource.TestCheckResourceAttr("julieops_kafka_consumer_acl.reader", "acl_bindings.#", "8"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:            "julieops_kafka_consumer_acl.reader",
+				ImportState:             true,
+				ImportStateId:           "#User:reader#",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata"},
+			},
+		},
+	})
+}
+
+func TestAccKafkaAclTopicsOnly(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), testResourceAcl_topicsOnly),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_consumer_acl.orders", "id", "#User:shared#*#orders,refunds"),
+					resource.TestCheckResourceAttr("julieops_kafka_consumer_acl.payments", "id", "#User:shared#*#payments#payments."),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      "julieops_kafka_consumer_acl.orders",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "julieops_kafka_consumer_acl.payments",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testResourceAcl_topicsOnly = `
+resource "julieops_kafka_consumer_acl" "orders" {
+  principal = "User:shared"
+  topics    = [ "orders", "refunds" ]
+}
+
+resource "julieops_kafka_consumer_acl" "payments" {
+  principal      = "User:shared"
+  topics         = [ "payments" ]
+  topic_prefixes = [ "payments." ]
+}
+`
+
+func TestAccKafkaAclImportAlongsideStreams(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), testResourceAcl_alongsideStreams),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaAclExist("julieops_kafka_consumer_acl.reader", "User:app"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      "julieops_kafka_consumer_acl.reader",
+				ImportState:       true,
+				ImportStateId:     "#User:app#",
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
 
+const testResourceAcl_alongsideStreams = `
+resource "julieops_kafka_consumer_acl" "reader" {
+  principal = "User:app"
+  topics = [ "orders" ]
+  groups {
+    name = "app.orders-reader"
+  }
+}
+
+resource "julieops_kafka_streams_acl" "streams" {
+  project = "app."
+  principal = "User:app"
+  read_topics = [ "payments" ]
+  write_topics = [ "audit" ]
+  exactly_once = true
+}
+`
+
+const testResourceAcl_topicsAndGroups = `
+resource "julieops_kafka_consumer_acl" "reader" {
+  principal = "User:reader"
+  topics = [ "other.orders", "other.payments" ]
+  topic_prefixes = [ "other.public." ]
+  groups {
+    name = "reader.app"
+  }
+  groups {
+    name = "reader."
+    pattern_type = "Prefixed"
+  }
+}
+`
+
+const testResourceAcl_hostsAndDeny = `
+resource "julieops_kafka_consumer_acl" "tenant" {
+  project = "tenant."
+  principal = "User:tenant"
+  group = "tenant"
+  hosts = [ "10.0.0.1", "10.0.0.2" ]
+  deny {
+    operations = [ "Read" ]
+    topics = [ "tenant.secrets" ]
+  }
+}
+
+# bindings of the same principal managed by other resources are left out when importing the bundle
+resource "julieops_kafka_acl" "tenant_audit" {
+  resource_type = "Topic"
+  resource_name = "audit"
+  principal     = "User:tenant"
+  host          = "10.0.0.9"
+  operation     = "Write"
+}
+
+resource "julieops_kafka_acl" "tenant_deny_other" {
+  resource_type = "Topic"
+  resource_name = "other.secrets"
+  principal     = "User:tenant"
+  operation     = "Read"
+  permission    = "Deny"
+}
+`
+
 const testResourceAcl_noConfig = `
 resource "julieops_kafka_consumer_acl" "consumer" {
   project = "%s"
@@ -56,12 +332,56 @@ func testAccKafkaAclDelete(s *terraform.State) error {
 		principal := rs.Primary.Attributes["principal"]
 		group := rs.Primary.Attributes["group"]
 
-		consumerAcl := client.NewConsumerAcl(project, principal, group, map[string]string{})
+		topics := stateList(rs.Primary.Attributes, "topics")
+		topicPrefixes := stateList(rs.Primary.Attributes, "topic_prefixes")
+
+		groups := make([]client.ConsumerGroup, 0)
+		for i := range stateList(rs.Primary.Attributes, "groups") {
+			groups = append(groups, client.ConsumerGroup{
+				Name:        rs.Primary.Attributes[fmt.Sprintf("groups.%d.name", i)],
+				PatternType: rs.Primary.Attributes[fmt.Sprintf("groups.%d.pattern_type", i)],
+			})
+		}
+
+		consumerAcl := client.NewConsumerAcl(project, principal, group, topics, topicPrefixes, groups, map[string]string{})
 		c.DeleteConsumerAcl(*consumerAcl)
 	}
 	return nil
 }
 
+// stateList reads back a flattened list attribute of a resource state.
+func stateList(attributes map[string]string, name string) []string {
+	count, _ := strconv.Atoi(attributes[name+".#"])
+	values := make([]string, count)
+	for i := range values {
+		values[i] = attributes[fmt.Sprintf("%s.%d", name, i)]
+	}
+	return values
+}
+
+// testAccKafkaAclDeleteRead removes the Read binding of a consumer behind terraform's back.
+func testAccKafkaAclDeleteRead(project string, principal string) {
+	c := testProvider.Meta().(*client.KafkaCluster)
+	resources, _ := client.KafkaAclsBuilder{Client: c}.ConsumerAclsBuilder(*client.NewConsumerAcl(project, principal, "*", nil, nil, nil, map[string]string{}))
+	for _, resource := range resources.Resources {
+		if resource.Resource.ResourceType == sarama.AclResourceTopic && resource.Acl.Operation == sarama.AclOperationRead {
+			c.DeleteAcls(client.AclResources{Resources: []client.AclResourceInfo{resource}})
+		}
+	}
+}
+
+// testAccKafkaAclGrantExtraRead allows the consumer to read the project topics from another host behind terraform's back.
+func testAccKafkaAclGrantExtraRead(project string, principal string, host string) {
+	c := testProvider.Meta().(*client.KafkaCluster)
+	resources, _ := client.KafkaAclsBuilder{Client: c}.ConsumerAclsBuilder(*client.NewConsumerAcl(project, principal, "*", nil, nil, nil, map[string]string{}))
+	for _, resource := range resources.Resources {
+		if resource.Resource.ResourceType == sarama.AclResourceTopic && resource.Acl.Operation == sarama.AclOperationRead {
+			resource.Acl.Host = host
+			c.ApplyAcls(client.AclResources{Resources: []client.AclResourceInfo{resource}})
+		}
+	}
+}
+
 func testAccKafkaAclExist(resourceName string, principalValue string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		resource, ok := s.RootModule().Resources[resourceName]
@@ -0,0 +1,217 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/Shopify/sarama"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"log"
+	"strings"
+	"terraform-provider-julieops/julie/client"
+)
+
+func resourceKafkaProducerAcl() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceKafkaProducerCreate,
+		ReadContext:   resourceKafkaProducerRead,
+		UpdateContext: resourceKafkaProducerUpdate,
+		DeleteContext: resourceKafkaProducerDelete,
+		CustomizeDiff: funcAclsCustomizeDiff(resourceAsProducerAcl, client.KafkaAclsBuilder{}.ProducerAclsBuilder),
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceKafkaProducerImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"project": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				AtLeastOneOf: []string{"project", "topics"},
+				Description:  "The project prefix used to build the resource ACLs",
+			},
+			"principal": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The user principal for this acl definition",
+			},
+			"topics": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				AtLeastOneOf: []string{"project", "topics"},
+				Description:  "The collection of topics the producer writes to",
+			},
+			"transactional_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The transactional.id used by the producer, if transactions are enabled",
+			},
+			"idempotence": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "True if the producer uses enable.idempotence and requires IdempotentWrite on the cluster",
+			},
+			"metadata": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Description: "Map of optional values describing metadata information for this producer",
+				Elem:        schema.TypeString,
+			},
+			"hosts":        hostsSchema(),
+			"deny":         denySchema(),
+			"acl_bindings": aclBindingsSchema(),
+		},
+	}
+}
+
+func resourceKafkaProducerCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	kafkaClient := m.(*client.KafkaCluster)
+	builder := client.KafkaAclsBuilder{
+		Client: kafkaClient,
+	}
+
+	aclInterface, err := funcCreateAclBundle(kafkaClient, builder, d, resourceAsProducerAcl, builder.ProducerAclsBuilder)
+	if err != nil {
+		return aclDiagnostics(err)
+	}
+	acl := aclInterface.(client.ProducerAcl)
+	d.SetId(acl.Id)
+	return nil
+}
+
+func resourceKafkaProducerRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	kafkaClient := m.(*client.KafkaCluster)
+	log.Printf("[DEBUG] producerAclRead: producerAcl=%s", d.Id())
+
+	builder := client.KafkaAclsBuilder{
+		Client: kafkaClient,
+	}
+
+	if err := funcReadAcls(kafkaClient, d, resourceAsProducerAcl, builder.ProducerAclsBuilder); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceKafkaProducerUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	kafkaClient := m.(*client.KafkaCluster)
+	builder := client.KafkaAclsBuilder{
+		Client: kafkaClient,
+	}
+
+	log.Printf("[DEBUG] Updating producer ACL(s) for %s", d.Id())
+
+	aclInterface, err := funcUpdateAcl(kafkaClient, d, resourceAsProducerAcl, builder.ProducerAclsBuilder)
+	if err != nil {
+		return aclDiagnostics(err)
+	}
+	acl := aclInterface.(client.ProducerAcl)
+	d.SetId(acl.Id)
+
+	return resourceKafkaProducerRead(ctx, d, m)
+}
+
+func resourceKafkaProducerDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	c := m.(*client.KafkaCluster)
+	builder := client.KafkaAclsBuilder{Client: c}
+
+	acl := resourceAsProducerAcl(d).(client.ProducerAcl)
+
+	log.Printf("[DEBUG] Deleting producer ACL(s) for %s", acl.Id)
+
+	resources, err := builder.ProducerAclsBuilder(acl)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	err = c.DeleteAcls(resources)
+
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceKafkaProducerImport accepts an id with the format project#principal#topic1,topic2, where the project
+// can be left empty for producers only granted on an explicit list of topics. Without the topics, every literal topic
+// the principal is granted Describe and Write on is imported.
+func resourceKafkaProducerImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.SplitN(d.Id(), "#", 3)
+	if len(parts) < 2 || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected project#principal or project#principal#topic1,topic2", d.Id())
+	}
+	project, principal := parts[0], parts[1]
+
+	var wantedTopics map[string]bool
+	if len(parts) == 3 && parts[2] != "" {
+		wantedTopics = make(map[string]bool)
+		for _, topic := range strings.Split(parts[2], ",") {
+			wantedTopics[topic] = true
+		}
+	}
+
+	c := m.(*client.KafkaCluster)
+	foundAcls, err := c.ListAcls(principal)
+	if err != nil {
+		return nil, err
+	}
+
+	// a producer is granted Describe and Write on its topics and literal transactional id, while streams and
+	// connect bundles only grant Write on their topics and streams prefixes its transactional ids
+	topics := make([]string, 0)
+	operations, literalTopics := literalTopicOperations(foundAcls, principal)
+	for _, topic := range literalTopics {
+		if operations[topic][sarama.AclOperationWrite] && operations[topic][sarama.AclOperationDescribe] &&
+			(wantedTopics == nil || wantedTopics[topic]) {
+			topics = append(topics, topic)
+		}
+	}
+
+	projectFound := false
+	for _, prefix := range allowedResources(foundAcls, principal, sarama.AclResourceTopic, sarama.AclPatternPrefixed,
+		sarama.AclOperationWrite, sarama.AclOperationDescribe) {
+		projectFound = projectFound || prefix == project
+	}
+
+	transactionalId := ""
+	transactionalIds := allowedResources(foundAcls, principal, sarama.AclResourceTransactionalID, sarama.AclPatternLiteral,
+		sarama.AclOperationWrite, sarama.AclOperationDescribe)
+	if len(transactionalIds) > 0 {
+		transactionalId = transactionalIds[0]
+	}
+
+	idempotence := len(allowedResources(foundAcls, principal, sarama.AclResourceCluster, sarama.AclPatternLiteral,
+		sarama.AclOperationIdempotentWrite)) > 0
+
+	if project != "" && !projectFound {
+		return nil, fmt.Errorf("no producer ACLs found for project %s and principal %s", project, principal)
+	}
+	if project == "" && len(topics) == 0 {
+		return nil, fmt.Errorf("no producer ACLs found for principal %s", principal)
+	}
+	if wantedTopics != nil && len(topics) != len(wantedTopics) {
+		return nil, fmt.Errorf("no producer ACLs found for all the topics %s of principal %s", parts[2], principal)
+	}
+
+	d.Set("project", project)
+	d.Set("principal", principal)
+	d.Set("topics", topics)
+	d.Set("transactional_id", transactionalId)
+	d.Set("idempotence", idempotence)
+	builder := client.KafkaAclsBuilder{Client: c}
+	if err := importAclRestrictions(d, foundAcls, principal, resourceAsProducerAcl, builder.ProducerAclsBuilder); err != nil {
+		return nil, err
+	}
+
+	acl := client.NewProducerAcl(project, principal, topics, transactionalId, idempotence, map[string]string{})
+	d.SetId(acl.Id)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,198 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"terraform-provider-julieops/julie/client"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccKafkaProducerAclCreate(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	project := "foo"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaProducerAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testResourceProducerAcl, project)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaProducerAclExist("julieops_kafka_producer_acl.producer", "User:producer"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:            "julieops_kafka_producer_acl.producer",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata"},
+			},
+		},
+	})
+}
+
+func TestAccKafkaProducerAclTopicsOnly(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaProducerAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), testResourceProducerAcl_topicsOnly),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_producer_acl.orders", "id", "#User:shared#orders,refunds"),
+					resource.TestCheckResourceAttr("julieops_kafka_producer_acl.payments", "id", "#User:shared#payments"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      "julieops_kafka_producer_acl.orders",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "julieops_kafka_producer_acl.payments",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKafkaProducerAclImportAlongsideStreams(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaProducerAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), testResourceProducerAcl_alongsideStreams),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_producer_acl.writer", "id", "#User:app#orders"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      "julieops_kafka_producer_acl.writer",
+				ImportState:       true,
+				ImportStateId:     "#User:app",
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+// the streams exactly once bindings include the cluster IdempotentWrite one, imported as idempotence
+const testResourceProducerAcl_alongsideStreams = `
+resource "julieops_kafka_producer_acl" "writer" {
+  principal = "User:app"
+  topics = [ "orders" ]
+  transactional_id = "orders-tx"
+  idempotence = true
+}
+
+resource "julieops_kafka_streams_acl" "streams" {
+  project = "app."
+  principal = "User:app"
+  read_topics = [ "payments" ]
+  write_topics = [ "audit" ]
+  exactly_once = true
+}
+`
+
+const testResourceProducerAcl_topicsOnly = `
+resource "julieops_kafka_producer_acl" "orders" {
+  principal = "User:shared"
+  topics    = [ "orders", "refunds" ]
+}
+
+resource "julieops_kafka_producer_acl" "payments" {
+  principal = "User:shared"
+  topics    = [ "payments" ]
+}
+`
+
+const testResourceProducerAcl = `
+resource "julieops_kafka_producer_acl" "producer" {
+  project          = "%s"
+  principal        = "User:producer"
+  topics           = [ "bar" ]
+  transactional_id = "producer-tx"
+  idempotence      = true
+  metadata = {
+    "foo" = "bar"
+  }
+}
+`
+
+func testAccKafkaProducerAclDelete(s *terraform.State) error {
+	c := testProvider.Meta().(*client.KafkaCluster)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "julieops_kafka_producer_acl" {
+			continue
+		}
+		project := rs.Primary.Attributes["project"]
+		principal := rs.Primary.Attributes["principal"]
+		transactionalId := rs.Primary.Attributes["transactional_id"]
+
+		topics := stateList(rs.Primary.Attributes, "topics")
+		idempotence := rs.Primary.Attributes["idempotence"] == "true"
+
+		acl := client.NewProducerAcl(project, principal, topics, transactionalId, idempotence, map[string]string{})
+		resources, err := client.KafkaAclsBuilder{Client: c}.ProducerAclsBuilder(*acl)
+		if err != nil {
+			return err
+		}
+		c.DeleteAcls(resources)
+	}
+	return nil
+}
+
+func testAccKafkaProducerAclExist(resourceName string, principalValue string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		resource, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("ACL(s) not found: %s", resourceName)
+		}
+
+		principal := resource.Primary.Attributes["principal"]
+		topics := resource.Primary.Attributes["topics.#"]
+		idempotence := resource.Primary.Attributes["idempotence"]
+
+		if principal != principalValue {
+			return fmt.Errorf("acl(s) %s with unexpected principal %s", resourceName, principal)
+		}
+
+		if topics != "1" {
+			return fmt.Errorf("acl(s) %s with unexpected number of topics %s", resourceName, topics)
+		}
+
+		if idempotence != "true" {
+			return fmt.Errorf("acl(s) %s with unexpected idempotence %s", resourceName, idempotence)
+		}
+
+		return nil
+	}
+}
@@ -2,9 +2,12 @@ package julie
 
 import (
 	"context"
+	"fmt"
+	"github.com/Shopify/sarama"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"log"
+	"strings"
 	"terraform-provider-julieops/julie/client"
 )
 
@@ -12,16 +15,17 @@ func resourceKafkaStreamsAcl() *schema.Resource {
 	return &schema.Resource{
 		CreateContext: resourceKafkaStreamsCreate,
 		ReadContext:   resourceKafkaStreamsRead,
+		UpdateContext: resourceKafkaStreamsUpdate,
 		DeleteContext: resourceKafkaStreamsDelete,
+		CustomizeDiff: funcAclsCustomizeDiff(resourceAsKafkaStreamsAcl, client.KafkaAclsBuilder{}.KafkaStreamsAclsBuilder),
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceKafkaStreamsImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"project": {
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
-				Description: "The project prefix used to build the resource ACLs",
+				Description: "The project prefix used to build the resource ACLs, the application is granted all the operations on the topics prefixed with it, also when application_id sets a different prefix",
 			},
 			"principal": {
 				Type:        schema.TypeString,
@@ -29,9 +33,13 @@ func resourceKafkaStreamsAcl() *schema.Resource {
 				ForceNew:    true,
 				Description: "The user principal for this acl definition",
 			},
+			"application_id": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The application.id of the Kafka Streams application, used as prefix for the internal topics, the consumer group and the transactional ids. Defaults to the project",
+			},
 			"read_topics": {
 				Type:     schema.TypeList,
-				ForceNew: true,
 				Optional: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
@@ -40,20 +48,43 @@ func resourceKafkaStreamsAcl() *schema.Resource {
 			},
 			"write_topics": {
 				Type:     schema.TypeList,
-				ForceNew: true,
 				Optional: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 				Description: "The collection of write topics for the Kafka Streams application",
 			},
+			"read_topic_prefixes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The collection of source topic prefixes for the Kafka Streams application",
+			},
+			"write_topic_prefixes": {
+				Type:     schema.TypeList,
+				Optional: true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+				Description: "The collection of write topic prefixes for the Kafka Streams application",
+			},
+			"exactly_once": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "True if the application uses processing.guarantee=exactly_once_v2 and requires transactional ACLs",
+			},
 			"metadata": {
 				Type:        schema.TypeMap,
 				Optional:    true,
-				ForceNew:    true,
 				Description: "Map of optional values describing metadata information for this consumer",
 				Elem:        schema.TypeString,
 			},
+			"hosts":        hostsSchema(),
+			"deny":         denySchema(),
+			"acl_bindings": aclBindingsSchema(),
 		},
 	}
 }
@@ -64,9 +95,9 @@ func resourceKafkaStreamsCreate(ctx context.Context, d *schema.ResourceData, m i
 		Client: kafkaClient,
 	}
 
-	aclInterface, err := funcCreateAcl(kafkaClient, builder, d, resourceAsKafkaStreamsAcl, builder.KafkaStreamsAclsBuilder)
+	aclInterface, err := funcCreateAclBundle(kafkaClient, builder, d, resourceAsKafkaStreamsAcl, builder.KafkaStreamsAclsBuilder)
 	if err != nil {
-		return diag.FromErr(err)
+		return aclDiagnostics(err)
 	}
 	acl := aclInterface.(client.KafkaStreamsAcl)
 	d.SetId(acl.Id)
@@ -80,18 +111,30 @@ func resourceKafkaStreamsRead(ctx context.Context, d *schema.ResourceData, m int
 	builder := client.KafkaAclsBuilder{
 		Client: kafkaClient,
 	}
-	kStreamAcl := resourceAsKafkaStreamsAcl(d).(client.KafkaStreamsAcl)
 
-	foundAcls, err := kafkaClient.ListAcls(kStreamAcl.Principal)
-	if err != nil {
+	if err := funcReadAcls(kafkaClient, d, resourceAsKafkaStreamsAcl, builder.KafkaStreamsAclsBuilder); err != nil {
 		return diag.FromErr(err)
 	}
 
-	funcSelectAclsFor(d, foundAcls, kStreamAcl, builder.KafkaStreamsAclShouldContinue, builder.KafkaStreamsAclsParser)
-
 	return nil
+}
 
-	return nil
+func resourceKafkaStreamsUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	kafkaClient := m.(*client.KafkaCluster)
+	builder := client.KafkaAclsBuilder{
+		Client: kafkaClient,
+	}
+
+	log.Printf("[DEBUG] Updating Kafka Streams ACL(s) for %s", d.Id())
+
+	aclInterface, err := funcUpdateAcl(kafkaClient, d, resourceAsKafkaStreamsAcl, builder.KafkaStreamsAclsBuilder)
+	if err != nil {
+		return aclDiagnostics(err)
+	}
+	acl := aclInterface.(client.KafkaStreamsAcl)
+	d.SetId(acl.Id)
+
+	return resourceKafkaStreamsRead(ctx, d, m)
 }
 
 func resourceKafkaStreamsDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -100,7 +143,7 @@ func resourceKafkaStreamsDelete(ctx context.Context, d *schema.ResourceData, m i
 	c := m.(*client.KafkaCluster)
 	acl := resourceAsKafkaStreamsAcl(d).(client.KafkaStreamsAcl)
 
-	log.Printf("[DEBUG] Deleting Kafka Streams ACL(s) for %s", acl)
+	log.Printf("[DEBUG] Deleting Kafka Streams ACL(s) for %s", acl.Id)
 
 	err := c.DeleteKafkaStreamsAcl(acl)
 
@@ -110,3 +153,74 @@ func resourceKafkaStreamsDelete(ctx context.Context, d *schema.ResourceData, m i
 
 	return diags
 }
+
+// resourceKafkaStreamsImport accepts an id with the format project#principal or project#principal#application_id
+// for an application with an application id other than the project. The read and write topics are rediscovered
+// from the topic ACLs of the principal.
+func resourceKafkaStreamsImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	parts := strings.Split(d.Id(), "#")
+	if len(parts) < 2 || len(parts) > 3 || parts[0] == "" || parts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%s), expected project#principal or project#principal#application_id", d.Id())
+	}
+	project, principal := parts[0], parts[1]
+	applicationId := ""
+	if len(parts) == 3 && parts[2] != project {
+		applicationId = parts[2]
+	}
+	appId := client.KafkaStreamsAcl{Project: project, ApplicationId: applicationId}.AppId()
+
+	c := m.(*client.KafkaCluster)
+	foundAcls, err := c.ListAcls(principal)
+	if err != nil {
+		return nil, err
+	}
+
+	readTopics := make([]string, 0)
+	writeTopics := make([]string, 0)
+	operations, topics := literalTopicOperations(foundAcls, principal)
+	for _, topic := range topics {
+		if operations[topic][sarama.AclOperationRead] {
+			readTopics = append(readTopics, topic)
+		}
+		if operations[topic][sarama.AclOperationWrite] {
+			writeTopics = append(writeTopics, topic)
+		}
+	}
+
+	groupFound := false
+	for _, group := range readableResources(foundAcls, principal, sarama.AclResourceGroup, sarama.AclPatternPrefixed) {
+		groupFound = groupFound || group == appId
+	}
+	if !groupFound {
+		return nil, fmt.Errorf("no Kafka Streams ACLs found for application %s and principal %s", appId, principal)
+	}
+
+	exactlyOnce := false
+	for _, transactionalId := range writableResources(foundAcls, principal, sarama.AclResourceTransactionalID, sarama.AclPatternPrefixed) {
+		exactlyOnce = exactlyOnce || transactionalId == appId
+	}
+	readTopicPrefixes := readableResources(foundAcls, principal, sarama.AclResourceTopic, sarama.AclPatternPrefixed)
+	writeTopicPrefixes := writableResources(foundAcls, principal, sarama.AclResourceTopic, sarama.AclPatternPrefixed)
+
+	d.Set("project", project)
+	d.Set("principal", principal)
+	d.Set("application_id", applicationId)
+	d.Set("read_topics", readTopics)
+	d.Set("write_topics", writeTopics)
+	d.Set("read_topic_prefixes", readTopicPrefixes)
+	d.Set("write_topic_prefixes", writeTopicPrefixes)
+	d.Set("exactly_once", exactlyOnce)
+	builder := client.KafkaAclsBuilder{Client: c}
+	if err := importAclRestrictions(d, foundAcls, principal, resourceAsKafkaStreamsAcl, builder.KafkaStreamsAclsBuilder); err != nil {
+		return nil, err
+	}
+
+	if err := funcVerifyImportedAcls(c, d, resourceAsKafkaStreamsAcl, builder.KafkaStreamsAclsBuilder); err != nil {
+		return nil, err
+	}
+
+	acl := resourceAsKafkaStreamsAcl(d).(client.KafkaStreamsAcl)
+	d.SetId(acl.Id)
+
+	return []*schema.ResourceData{d}, nil
+}
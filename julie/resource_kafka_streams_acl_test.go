@@ -31,10 +31,243 @@ func TestAccKafkaStreamsAclCreate(t *testing.T) {
 				),
 				ExpectNonEmptyPlan: false,
 			},
+			{
+				ResourceName:            "julieops_kafka_streams_acl.streams",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata"},
+			},
 		},
 	})
 }
 
+func TestAccKafkaStreamsAclUpdate(t *testing.T) {
+
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	project := "foo"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaStreamsAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testKafkaStreamsResourceAcl_noConfig, project)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaStreamsAclExist("julieops_kafka_streams_acl.streams", "User:streams"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testKafkaStreamsResourceAcl_moreTopics, project)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaStreamsAclExist("julieops_kafka_streams_acl.streams", "User:streams"),
+					resource.TestCheckResourceAttr("julieops_kafka_streams_acl.streams", "read_topics.#", "2"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func TestAccKafkaStreamsAclExactlyOnce(t *testing.T) {
+
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	project := "foo"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaStreamsAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testKafkaStreamsResourceAcl_exactlyOnce, project)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaStreamsAclExist("julieops_kafka_streams_acl.streams", "User:streams"),
+					resource.TestCheckResourceAttr("julieops_kafka_streams_acl.streams", "acl_bindings.#", "10"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:            "julieops_kafka_streams_acl.streams",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"metadata"},
+			},
+		},
+	})
+}
+
+func TestAccKafkaStreamsAclApplicationIdAndPrefixes(t *testing.T) {
+
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	project := "foo"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaStreamsAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testKafkaStreamsResourceAcl_prefixes, project)),
+				Check: resource.ComposeTestCheckFunc(
+					testAccKafkaStreamsAclExist("julieops_kafka_streams_acl.streams", "User:streams"),
+					resource.TestCheckResourceAttr("julieops_kafka_streams_acl.streams", "acl_bindings.#", "5"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      "julieops_kafka_streams_acl.streams",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccKafkaStreamsAclProjectAndApplicationId(t *testing.T) {
+
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	project := "foo"
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaStreamsAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testKafkaStreamsResourceAcl_noConfig, project)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_streams_acl.streams", "acl_bindings.#", "4"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testKafkaStreamsResourceAcl_applicationId, project)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_streams_acl.streams", "acl_bindings.#", "5"),
+					resource.TestCheckTypeSetElemAttr("julieops_kafka_streams_acl.streams", "acl_bindings.*",
+						"(principal=User:streams, host=*, operation=All, permission=Allow) on Topic foo (Prefixed)"),
+					resource.TestCheckTypeSetElemAttr("julieops_kafka_streams_acl.streams", "acl_bindings.*",
+						"(principal=User:streams, host=*, operation=All, permission=Allow) on Topic foo-app (Prefixed)"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+		},
+	})
+}
+
+func TestAccKafkaStreamsAclApplicationsOfProject(t *testing.T) {
+
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccKafkaStreamsAclDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), testKafkaStreamsResourceAcl_applications),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_kafka_streams_acl.one", "id", "foo#User:streams#foo-one"),
+					resource.TestCheckResourceAttr("julieops_kafka_streams_acl.two", "id", "foo#User:streams#foo-two"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      "julieops_kafka_streams_acl.one",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				ResourceName:      "julieops_kafka_streams_acl.two",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testKafkaStreamsResourceAcl_applications = `
+resource "julieops_kafka_streams_acl" "one" {
+  project = "foo"
+  principal = "User:streams"
+  application_id = "foo-one"
+}
+
+resource "julieops_kafka_streams_acl" "two" {
+  project = "foo"
+  principal = "User:streams"
+  application_id = "foo-two"
+}
+`
+
+const testKafkaStreamsResourceAcl_applicationId = `
+resource "julieops_kafka_streams_acl" "streams" {
+  project = "%s"
+  principal = "User:streams"
+  application_id = "foo-app"
+  read_topics = [ "foo" ]
+  write_topics = [ "bar" ]
+  metadata = {
+    "foo" = "bar"
+  }
+}
+`
+
+const testKafkaStreamsResourceAcl_prefixes = `
+resource "julieops_kafka_streams_acl" "streams" {
+  project = "%s"
+  principal = "User:streams"
+  application_id = "foo-app"
+  read_topic_prefixes = [ "source." ]
+  write_topic_prefixes = [ "sink." ]
+}
+`
+
+const testKafkaStreamsResourceAcl_exactlyOnce = `
+resource "julieops_kafka_streams_acl" "streams" {
+  project = "%s"
+  principal = "User:streams"
+  application_id = "foo-app"
+  read_topics = [ "foo" ]
+  write_topics = [ "bar" ]
+  exactly_once = true
+}
+`
+
+const testKafkaStreamsResourceAcl_moreTopics = `
+resource "julieops_kafka_streams_acl" "streams" {
+  project = "%s"
+  principal = "User:streams"
+  read_topics = [ "foo", "zet" ]
+  write_topics = [ "bar" ]
+  metadata = {
+    "foo" = "bar"
+  }
+}
+`
+
 const testKafkaStreamsResourceAcl_noConfig = `
 resource "julieops_kafka_streams_acl" "streams" {
   project = "%s"
@@ -59,7 +292,7 @@ func testAccKafkaStreamsAclDelete(s *terraform.State) error {
 
 		//TODO: To be accurate should retrieve the arrays read_topics and write topics, so the acls
 		// are not leave in the cluster.... need to find out how...
-		acl := client.NewKafkaStreamsAcl(project, principal, []string{}, []string{}, map[string]string{})
+		acl := client.NewKafkaStreamsAcl(project, principal, "", []string{}, []string{}, []string{}, []string{}, false, map[string]string{})
 		c.DeleteKafkaStreamsAcl(*acl)
 	}
 	return nil
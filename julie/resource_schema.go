@@ -0,0 +1,301 @@
+package julie
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"strings"
+	"terraform-provider-julieops/julie/client"
+)
+
+func resourceSchema() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSchemaCreate,
+		ReadContext:   resourceSchemaRead,
+		UpdateContext: resourceSchemaUpdate,
+		DeleteContext: resourceSchemaDelete,
+		CustomizeDiff: resourceSchemaCustomizeDiff,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSchemaImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"subject": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ForceNew:      true,
+				ConflictsWith: []string{"topic", "record_name"},
+				AtLeastOneOf:  []string{"subject", "topic", "record_name"},
+				Description:   "The subject the schema is registered under, derived from topic and record_name when not set",
+			},
+			"topic": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressWithSubject,
+				Description:      "The topic of the schema, uses the TopicNameStrategy, or the TopicRecordNameStrategy with record_name",
+			},
+			"record_name": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressWithSubject,
+				Description:      "The fully qualified record name of the schema, uses the RecordNameStrategy",
+			},
+			"is_key": {
+				Type:             schema.TypeBool,
+				Optional:         true,
+				Default:          false,
+				ForceNew:         true,
+				DiffSuppressFunc: suppressWithSubject,
+				Description:      "Whether the schema is the topic key schema, used with the TopicNameStrategy",
+			},
+			"schema": {
+				Type:             schema.TypeString,
+				Required:         true,
+				DiffSuppressFunc: suppressEquivalentSchema,
+				Description:      "The schema definition",
+			},
+			"schema_type": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      "AVRO",
+				ValidateFunc: validation.StringInSlice([]string{"AVRO", "PROTOBUF", "JSON"}, false),
+				Description:  "The schema type, AVRO, PROTOBUF or JSON",
+			},
+			"references": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "The schemas referenced by this schema",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The reference name, as used in the schema",
+						},
+						"subject": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The subject of the referenced schema",
+						},
+						"version": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The version of the referenced schema",
+						},
+					},
+				},
+			},
+			"hard_delete": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Permanently delete the subject on destroy instead of soft deleting it",
+			},
+			"schema_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The global id of the registered schema",
+			},
+			"version": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The version of the schema under its subject",
+			},
+		},
+	}
+}
+
+func resourceSchemaCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subject := schemaSubject(d)
+	if err := registerSchema(sr, subject, d); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(subject)
+	return resourceSchemaRead(ctx, d, m)
+}
+
+func resourceSchemaRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subject := d.Id()
+	log.Printf("[DEBUG] reading the latest schema of subject %s", subject)
+
+	latest, err := sr.GetSchema(subject, "latest")
+	if errors.Is(err, client.ErrSchemaNotFound) {
+		log.Printf("[WARN] subject %s not found, removing it from state", subject)
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// keep the configured schema text unless the latest version differs from it
+	current := d.Get("schema").(string)
+	if client.NormalizeSchema(current, latest.SchemaType) != client.NormalizeSchema(latest.Schema, latest.SchemaType) {
+		d.Set("schema", latest.Schema)
+	}
+
+	d.Set("subject", latest.Subject)
+	d.Set("schema_type", latest.SchemaType)
+	d.Set("references", schemaReferencesAsInterfaces(latest.References))
+	d.Set("schema_id", latest.Id)
+	d.Set("version", latest.Version)
+
+	return nil
+}
+
+func resourceSchemaUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if d.HasChanges("schema", "schema_type", "references") {
+		log.Printf("[DEBUG] registering a new schema version for subject %s", d.Id())
+		if err := registerSchema(sr, d.Id(), d); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceSchemaRead(ctx, d, m)
+}
+
+func resourceSchemaDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subject := d.Id()
+	hardDelete := d.Get("hard_delete").(bool)
+	log.Printf("[DEBUG] deleting subject %s, hard delete %t", subject, hardDelete)
+
+	err = sr.DeleteSubject(subject, hardDelete)
+	if err != nil && !errors.Is(err, client.ErrSchemaNotFound) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceSchemaImport accepts the subject as id. The topic and is_key are derived from a subject named with the
+// TopicNameStrategy, <topic>-key or <topic>-value. The record_name cannot be derived from the subject, schemas
+// registered with the RecordNameStrategy or TopicRecordNameStrategy are configured with the subject to be imported.
+func resourceSchemaImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	subject := d.Id()
+	topic, isKey := "", false
+	switch {
+	case strings.HasSuffix(subject, "-key"):
+		topic, isKey = strings.TrimSuffix(subject, "-key"), true
+	case strings.HasSuffix(subject, "-value"):
+		topic = strings.TrimSuffix(subject, "-value")
+	}
+
+	d.Set("topic", topic)
+	d.Set("is_key", isKey)
+	d.Set("hard_delete", false)
+	return []*schema.ResourceData{d}, nil
+}
+
+// suppressWithSubject ignores the topic, record_name and is_key when the subject is configured, as only a change of
+// the subject replaces the schema. It keeps the topic derived on import from replacing a schema configured with
+// its subject.
+func suppressWithSubject(k, old, new string, d *schema.ResourceData) bool {
+	config := d.GetRawConfig()
+	if config.IsNull() || !config.IsKnown() {
+		return false
+	}
+	subject := config.GetAttr("subject")
+	return subject.IsKnown() && !subject.IsNull()
+}
+
+// resourceSchemaCustomizeDiff fails the plan when the new schema is incompatible with the latest registered version.
+func resourceSchemaCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	for _, key := range []string{"topic", "record_name", "schema", "schema_type", "references"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
+	}
+
+	subject := d.Id()
+	if subject == "" {
+		subject = schemaSubject(d)
+	} else if !d.HasChange("schema") && !d.HasChange("schema_type") && !d.HasChange("references") {
+		return nil
+	}
+	if subject == "" {
+		return nil
+	}
+
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return err
+	}
+	log.Printf("[DEBUG] checking the schema compatibility with the latest version of subject %s", subject)
+
+	response, err := sr.TestCompatibility(subject, resourceAsRegisterSchemaRequest(d))
+	if errors.Is(err, client.ErrSchemaNotFound) {
+		// a new subject accepts any schema
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if !response.IsCompatible {
+		return fmt.Errorf("schema is incompatible with the latest version of subject %s: %s", subject, strings.Join(response.Messages, "; "))
+	}
+	return nil
+}
+
+func registerSchema(sr *client.SchemaRegistryCluster, subject string, d *schema.ResourceData) error {
+	request := resourceAsRegisterSchemaRequest(d)
+
+	response, err := sr.RegisterSchema(subject, request)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("[DEBUG] schema registered under subject %s with id %d", subject, response.Id)
+	return nil
+}
+
+// schemaSubject resolves the subject of a schema, either set explicitly or derived with a subject name strategy.
+func schemaSubject(d client.ResourceGetter) string {
+	subject := d.Get("subject").(string)
+	if subject != "" {
+		return subject
+	}
+
+	topic := d.Get("topic").(string)
+	recordName := d.Get("record_name").(string)
+	switch {
+	case topic != "" && recordName != "":
+		return client.TopicRecordNameStrategy(topic, recordName)
+	case topic != "":
+		return client.TopicNameStrategy(topic, d.Get("is_key").(bool))
+	default:
+		return client.RecordNameStrategy(recordName)
+	}
+}
+
+func suppressEquivalentSchema(k, old, new string, d *schema.ResourceData) bool {
+	schemaType := d.Get("schema_type").(string)
+	return client.NormalizeSchema(old, schemaType) == client.NormalizeSchema(new, schemaType)
+}
@@ -0,0 +1,129 @@
+package julie
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"terraform-provider-julieops/julie/client"
+)
+
+// globalSettingId is the id of a Schema Registry setting applying to all subjects.
+const globalSettingId = "__GLOBAL"
+
+// defaultCompatibility is the Schema Registry global compatibility level restored on destroy.
+const defaultCompatibility = "BACKWARD"
+
+var compatibilityLevels = []string{
+	"BACKWARD", "BACKWARD_TRANSITIVE", "FORWARD", "FORWARD_TRANSITIVE", "FULL", "FULL_TRANSITIVE", "NONE",
+}
+
+func resourceSchemaCompatibility() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSchemaCompatibilityUpdate,
+		ReadContext:   resourceSchemaCompatibilityRead,
+		UpdateContext: resourceSchemaCompatibilityUpdate,
+		DeleteContext: resourceSchemaCompatibilityDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSchemaSettingImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"subject": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The subject the compatibility level applies to, the global compatibility level when not set",
+			},
+			"compatibility": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice(compatibilityLevels, false),
+				Description:  "The compatibility level, BACKWARD, BACKWARD_TRANSITIVE, FORWARD, FORWARD_TRANSITIVE, FULL, FULL_TRANSITIVE or NONE",
+			},
+		},
+	}
+}
+
+func resourceSchemaCompatibilityUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subject := d.Get("subject").(string)
+	compatibility := d.Get("compatibility").(string)
+	log.Printf("[DEBUG] setting compatibility level %s for subject %s", compatibility, settingId(subject))
+
+	if err := sr.SetCompatibility(subject, compatibility); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(settingId(subject))
+	return resourceSchemaCompatibilityRead(ctx, d, m)
+}
+
+func resourceSchemaCompatibilityRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subject := settingSubject(d.Id())
+	compatibility, err := sr.GetCompatibility(subject)
+	if errors.Is(err, client.ErrSchemaNotFound) {
+		log.Printf("[WARN] no compatibility level found for %s, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("subject", subject)
+	d.Set("compatibility", compatibility)
+
+	return nil
+}
+
+func resourceSchemaCompatibilityDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subject := settingSubject(d.Id())
+	log.Printf("[DEBUG] removing the compatibility level of %s", d.Id())
+
+	if subject == "" {
+		err = sr.SetCompatibility(subject, defaultCompatibility)
+	} else {
+		err = sr.DeleteCompatibility(subject)
+	}
+	if err != nil && !errors.Is(err, client.ErrSchemaNotFound) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceSchemaSettingImport accepts the subject, or __GLOBAL for the global setting, as id.
+func resourceSchemaSettingImport(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+	d.Set("subject", settingSubject(d.Id()))
+	return []*schema.ResourceData{d}, nil
+}
+
+func settingId(subject string) string {
+	if subject == "" {
+		return globalSettingId
+	}
+	return subject
+}
+
+func settingSubject(id string) string {
+	if id == globalSettingId {
+		return ""
+	}
+	return id
+}
@@ -0,0 +1,111 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"terraform-provider-julieops/julie/client"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccSchemaCompatibilitySubject(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{EnableSchemaRegistry: true}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccSchemaCompatibilityDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, fmt.Sprintf(testResourceSchemaCompatibility_subject, "FULL")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_schema_compatibility.orders", "id", "orders-value"),
+					resource.TestCheckResourceAttr("julieops_schema_compatibility.orders", "compatibility", "FULL"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, fmt.Sprintf(testResourceSchemaCompatibility_subject, "FULL_TRANSITIVE")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_schema_compatibility.orders", "compatibility", "FULL_TRANSITIVE"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      "julieops_schema_compatibility.orders",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func TestAccSchemaCompatibilityGlobal(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{EnableSchemaRegistry: true}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccSchemaCompatibilityDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, testResourceSchemaCompatibility_global),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_schema_compatibility.global", "id", globalSettingId),
+					resource.TestCheckResourceAttr("julieops_schema_compatibility.global", "compatibility", "NONE"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:      "julieops_schema_compatibility.global",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+const testResourceSchemaCompatibility_subject = `
+resource "julieops_schema_compatibility" "orders" {
+  subject = "orders-value"
+  compatibility = "%s"
+}
+`
+
+const testResourceSchemaCompatibility_global = `
+resource "julieops_schema_compatibility" "global" {
+  compatibility = "NONE"
+}
+`
+
+func testAccSchemaCompatibilityDelete(s *terraform.State) error {
+	c := testProvider.Meta().(*client.KafkaCluster)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "julieops_schema_compatibility" {
+			continue
+		}
+		subject := settingSubject(rs.Primary.ID)
+		if subject == "" {
+			compatibility, err := c.SchemaRegistryClient.GetCompatibility(subject)
+			if err == nil && compatibility != defaultCompatibility {
+				return fmt.Errorf("global compatibility level not restored, found %s", compatibility)
+			}
+			continue
+		}
+		if _, err := c.SchemaRegistryClient.GetCompatibility(subject); err == nil {
+			return fmt.Errorf("compatibility level of %s still exists", subject)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+package julie
+
+import (
+	"context"
+	"errors"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"log"
+	"terraform-provider-julieops/julie/client"
+)
+
+// defaultMode is the Schema Registry global mode restored on destroy.
+const defaultMode = "READWRITE"
+
+func resourceSchemaRegistryMode() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceSchemaRegistryModeUpdate,
+		ReadContext:   resourceSchemaRegistryModeRead,
+		UpdateContext: resourceSchemaRegistryModeUpdate,
+		DeleteContext: resourceSchemaRegistryModeDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceSchemaSettingImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"subject": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The subject the mode applies to, the global mode when not set",
+			},
+			"mode": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringInSlice([]string{"READWRITE", "READONLY", "READONLY_OVERRIDE", "IMPORT"}, false),
+				Description:  "The mode, READWRITE, READONLY, READONLY_OVERRIDE or IMPORT",
+			},
+			"force": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Allow switching to IMPORT mode while schemas are registered",
+			},
+		},
+	}
+}
+
+func resourceSchemaRegistryModeUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subject := d.Get("subject").(string)
+	mode := d.Get("mode").(string)
+	log.Printf("[DEBUG] setting mode %s for subject %s", mode, settingId(subject))
+
+	if err := sr.SetMode(subject, mode, d.Get("force").(bool)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(settingId(subject))
+	return resourceSchemaRegistryModeRead(ctx, d, m)
+}
+
+func resourceSchemaRegistryModeRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subject := settingSubject(d.Id())
+	mode, err := sr.GetMode(subject)
+	if errors.Is(err, client.ErrSchemaNotFound) {
+		log.Printf("[WARN] no mode found for %s, removing it from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("subject", subject)
+	d.Set("mode", mode)
+
+	return nil
+}
+
+func resourceSchemaRegistryModeDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	sr, err := m.(*client.KafkaCluster).SchemaRegistry()
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	subject := settingSubject(d.Id())
+	log.Printf("[DEBUG] removing the mode of %s", d.Id())
+
+	if subject == "" {
+		err = sr.SetMode(subject, defaultMode, false)
+	} else {
+		err = sr.DeleteMode(subject)
+	}
+	if err != nil && !errors.Is(err, client.ErrSchemaNotFound) {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,70 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"terraform-provider-julieops/julie/client"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccSchemaRegistryModeSubject(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{EnableSchemaRegistry: true}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccSchemaRegistryModeDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, fmt.Sprintf(testResourceSchemaRegistryMode_subject, "READONLY")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_schema_registry_mode.orders", "mode", "READONLY"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, fmt.Sprintf(testResourceSchemaRegistryMode_subject, "IMPORT")),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_schema_registry_mode.orders", "mode", "IMPORT"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:            "julieops_schema_registry_mode.orders",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force"},
+			},
+		},
+	})
+}
+
+const testResourceSchemaRegistryMode_subject = `
+resource "julieops_schema_registry_mode" "orders" {
+  subject = "orders-value"
+  mode = "%s"
+}
+`
+
+func testAccSchemaRegistryModeDelete(s *terraform.State) error {
+	c := testProvider.Meta().(*client.KafkaCluster)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "julieops_schema_registry_mode" {
+			continue
+		}
+		subject := settingSubject(rs.Primary.ID)
+		mode, err := c.SchemaRegistryClient.GetMode(subject)
+		if err == nil && subject != "" && mode != defaultMode {
+			return fmt.Errorf("mode of %s still set to %s", subject, mode)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+package julie
+
+import (
+	"context"
+	"fmt"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	"terraform-provider-julieops/julie/client"
+	julieTest "terraform-provider-julieops/julie/test"
+	"testing"
+)
+
+func TestAccSchemaCreate(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{EnableSchemaRegistry: true}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccSchemaDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, fmt.Sprintf(testResourceSchema_topic, testSchemaV1)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_schema.orders", "subject", "orders-value"),
+					resource.TestCheckResourceAttr("julieops_schema.orders", "version", "1"),
+					resource.TestCheckResourceAttrSet("julieops_schema.orders", "schema_id"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config: cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, fmt.Sprintf(testResourceSchema_topic, testSchemaV2)),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_schema.orders", "version", "2"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:            "julieops_schema.orders",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"schema", "hard_delete"},
+			},
+		},
+	})
+}
+
+func TestAccSchemaImportKey(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{EnableSchemaRegistry: true}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccSchemaDelete,
+		Steps: []resource.TestStep{
+			{
+				Config: cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, testResourceSchema_key),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("julieops_schema.orders_key", "subject", "orders-key"),
+				),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				ResourceName:            "julieops_schema.orders_key",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"schema", "hard_delete"},
+			},
+		},
+	})
+}
+
+func TestAccSchemaDriftDetection(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{EnableSchemaRegistry: true}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccSchemaDelete,
+		Steps: []resource.TestStep{
+			{
+				Config:             cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, fmt.Sprintf(testResourceSchema_topic, testSchemaV1)),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				PreConfig: func() {
+					c := testProvider.Meta().(*client.KafkaCluster)
+					c.SchemaRegistryClient.RegisterSchema("orders-value", client.RegisterSchemaRequest{Schema: testSchemaV2})
+				},
+				Config:             cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, fmt.Sprintf(testResourceSchema_topic, testSchemaV1)),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
+func TestAccSchemaIncompatible(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{EnableSchemaRegistry: true}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		CheckDestroy: testAccSchemaDelete,
+		Steps: []resource.TestStep{
+			{
+				Config:             cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, fmt.Sprintf(testResourceSchema_topic, testSchemaV1)),
+				ExpectNonEmptyPlan: false,
+			},
+			{
+				Config:      cfgWithSchemaRegistry(setup.AkContainer.URI, setup.SrContainer.URI, fmt.Sprintf(testResourceSchema_topic, testSchemaIncompatible)),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile("schema is incompatible with the latest version of subject orders-value"),
+			},
+		},
+	})
+}
+
+func TestAccSchemaRegistryNotConfigured(t *testing.T) {
+	ctx := context.Background()
+	setup, close := julieTest.SetupDocker(ctx, julieTest.ContainersSetupConfig{}, t)
+	defer close(ctx)
+
+	resource.Test(t, resource.TestCase{
+		ProviderFactories: overrideProviderFactory(),
+		PreCheck: func() {
+			testAccPreCheck(t)
+		},
+		Steps: []resource.TestStep{
+			{
+				Config:      cfg(setup.AkContainer.URI, kafkaConnectServerFromEnv(), fmt.Sprintf(testResourceSchema_topic, testSchemaV1)),
+				ExpectError: regexp.MustCompile("schema_registry is not configured in the provider"),
+			},
+		},
+	})
+}
+
+const testSchemaV1 = `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}]}`
+
+const testSchemaV2 = `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}, {"name": "amount", "type": "double", "default": 0.0}]}`
+
+// testSchemaIncompatible adds a field without a default, which is not BACKWARD compatible
+const testSchemaIncompatible = `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "string"}, {"name": "customer", "type": "string"}]}`
+
+const testResourceSchema_topic = `
+resource "julieops_schema" "orders" {
+  topic = "orders"
+  schema = <<EOT
+%s
+EOT
+  hard_delete = true
+}
+`
+
+const testResourceSchema_key = `
+resource "julieops_schema" "orders_key" {
+  topic = "orders"
+  is_key = true
+  schema = jsonencode({ "type" = "string" })
+  hard_delete = true
+}
+`
+
+func cfgWithSchemaRegistry(bs string, schemaRegistry string, extraCfg string) string {
+	var saslConfig = " \t sasl_username =  \"kafka\" \n \t sasl_password = \"kafka\" \n \t sasl_mechanism = \"plain\"  \n "
+	var connectConfig = fmt.Sprintf("\t kafka_connects = \"%s\" \n", kafkaConnectServerFromEnv())
+	var schemaRegistryConfig = fmt.Sprintf("\t schema_registry { \n \t url = \"%s\" \n } \n", schemaRegistry)
+	var str = "provider \"julieops\" { \n \t bootstrap_servers = \"%s\" \n %s %s %s } \n %s \n"
+	return fmt.Sprintf(str, bs, connectConfig, saslConfig, schemaRegistryConfig, extraCfg)
+}
+
+func testAccSchemaDelete(s *terraform.State) error {
+	c := testProvider.Meta().(*client.KafkaCluster)
+
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "julieops_schema" {
+			continue
+		}
+		c.SchemaRegistryClient.DeleteSubject(rs.Primary.ID, true)
+	}
+	return nil
+}
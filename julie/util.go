@@ -1,9 +1,16 @@
 package julie
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"github.com/Shopify/sarama"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"log"
+	"sort"
+	"strings"
 	"terraform-provider-julieops/julie/client"
 )
 
@@ -25,19 +32,416 @@ func funcCreateAcl(c *client.KafkaCluster, builder client.KafkaAclsBuilder,
 	return aclInterface, nil
 }
 
-type fnShouldContinue func(entity sarama.ResourceAcls, aclInterface interface{}) bool
-type fnAclParser func(d *schema.ResourceData, aclInterface interface{}, aclEntity sarama.ResourceAcls) error
+// funcCreateAclBundle creates the bindings of a bundle resource and stores them in its acl_bindings attribute.
+func funcCreateAclBundle(c *client.KafkaCluster, builder client.KafkaAclsBuilder,
+	d *schema.ResourceData, fnConvert client.Convert, fnBuilder client.AclBuilder) (interface{}, error) {
+
+	aclInterface, err := funcCreateAcl(c, builder, d, fnConvert, fnBuilder)
+	if err != nil {
+		return nil, err
+	}
+
+	acls, err := fnBuilder(aclInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("acl_bindings", acls.Strings()); err != nil {
+		return nil, err
+	}
+
+	return aclInterface, nil
+}
+
+// funcUpdateAcl moves the cluster from the bindings currently present for the prior state to the ones built
+// out of the new configuration. Added bindings are created before removed ones are deleted, so an application
+// never loses access it keeps in the new configuration. Bindings missing from the cluster are created again.
+func funcUpdateAcl(c *client.KafkaCluster, d *schema.ResourceData, fnConvert client.Convert, fnBuilder client.AclBuilder) (interface{}, error) {
+
+	oldResources, err := fnBuilder(fnConvert(priorState{d: d}))
+	if err != nil {
+		return nil, err
+	}
+
+	aclInterface := fnConvert(d)
+	newResources, err := fnBuilder(aclInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	candidates := client.AclResources{Resources: append(oldResources.Resources, newResources.Resources...)}
+	actualResources, err := presentAcls(c, candidates)
+	if err != nil {
+		return nil, err
+	}
+
+	added, removed := client.DiffAclResources(actualResources, newResources)
+	log.Printf("[INFO] Updating ACL(s), adding %d and removing %d binding(s)", len(added.Resources), len(removed.Resources))
+
+	if err := c.ApplyAcls(added); err != nil {
+		return nil, err
+	}
+
+	if err := c.DeleteAcls(removed); err != nil {
+		return nil, err
+	}
+
+	if err := d.Set("acl_bindings", newResources.Strings()); err != nil {
+		return nil, err
+	}
 
-func funcSelectAclsFor(d *schema.ResourceData, foundAcls []sarama.ResourceAcls, aclInterface interface{},
-	shouldContinue fnShouldContinue, parser fnAclParser) {
-	for _, aclEntity := range foundAcls {
-		if shouldContinue(aclEntity, aclInterface) {
+	return aclInterface, nil
+}
+
+// aclDiagnostics converts an error returned while creating ACLs into diagnostics, reporting each
+// refused binding as its own error.
+func aclDiagnostics(err error) diag.Diagnostics {
+	var creationError client.AclCreationError
+	if !errors.As(err, &creationError) {
+		return diag.FromErr(err)
+	}
+
+	var diags diag.Diagnostics
+	for _, failure := range creationError.Failures {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Error,
+			Summary:  fmt.Sprintf("ACL could not be created: %s", failure.Err),
+			Detail:   client.DescribeAclResource(failure.Resource),
+		})
+	}
+	return diags
+}
+
+// funcReadAcls rebuilds the bindings expected for the resource state and stores the ones actually present in
+// the cluster in the acl_bindings attribute, so both missing and extra bindings show up as drift. The resource
+// is removed from the state if none of the expected bindings exist.
+func funcReadAcls(c *client.KafkaCluster, d *schema.ResourceData, fnConvert client.Convert, fnBuilder client.AclBuilder) error {
+	expected, err := fnBuilder(fnConvert(d))
+	if err != nil {
+		return err
+	}
+
+	present, err := presentAcls(c, expected)
+	if err != nil {
+		return err
+	}
+
+	missing, extra := client.DiffAclResources(present, expected)
+	found := len(expected.Resources) - len(missing.Resources)
+	log.Printf("[INFO] ACL(s) found %d out of %d expected, %d extra, for %s", found, len(expected.Resources), len(extra.Resources), d.Id())
+
+	if found == 0 {
+		log.Printf("[WARN] No ACL(s) found for %s, removing from state", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	return d.Set("acl_bindings", present.Strings())
+}
+
+// presentAcls returns the bindings of the principals present in the cluster on the resources and for the
+// operations the given bindings manage. Besides the given bindings found, it holds the extra ones, such as an
+// operation allowed from another host, while bindings other resources manage on unrelated resources or
+// operations of the same principal are left out. Each principal's bindings are fetched once.
+func presentAcls(c *client.KafkaCluster, resources client.AclResources) (client.AclResources, error) {
+	managed := managedOperations(resources)
+	principals := make([]string, 0)
+	listed := make(map[string]bool)
+	for _, resource := range resources.Resources {
+		if !listed[resource.Acl.Principal] {
+			listed[resource.Acl.Principal] = true
+			principals = append(principals, resource.Acl.Principal)
+		}
+	}
+
+	present := client.AclResources{Resources: make([]client.AclResourceInfo, 0)}
+	for _, principal := range principals {
+		principalAcls, err := c.ListAclResources(principal)
+		if err != nil {
+			return present, err
+		}
+		for _, resource := range principalAcls.Resources {
+			if managed[managedOperation{resource.Resource, resource.Acl.Operation}] {
+				present.Resources = append(present.Resources, resource)
+			}
+		}
+	}
+	return present, nil
+}
+
+// managedOperation is an operation on a resource, with its pattern type, managed by a bundle resource.
+type managedOperation struct {
+	resource  sarama.Resource
+	operation sarama.AclOperation
+}
+
+// managedOperations indexes the operations on resources the given bindings manage, whatever their host and permission.
+func managedOperations(resources client.AclResources) map[managedOperation]bool {
+	managed := make(map[managedOperation]bool)
+	for _, resource := range resources.Resources {
+		managed[managedOperation{resource.Resource, resource.Acl.Operation}] = true
+	}
+	return managed
+}
+
+// funcAclsCustomizeDiff plans the acl_bindings attribute to the bindings expected for the configuration, so any
+// binding missing from the cluster shows up in the plan.
+func funcAclsCustomizeDiff(fnConvert client.Convert, fnBuilder client.AclBuilder) schema.CustomizeDiffFunc {
+	return func(ctx context.Context, diff *schema.ResourceDiff, m interface{}) error {
+		if diff.Id() == "" {
+			return nil
+		}
+
+		for _, key := range diff.GetChangedKeysPrefix("") {
+			if !diff.NewValueKnown(key) {
+				return diff.SetNewComputed("acl_bindings")
+			}
+		}
+
+		expected, err := fnBuilder(fnConvert(diff))
+		if err != nil {
+			return err
+		}
+
+		actual := diff.Get("acl_bindings").(*schema.Set)
+		expectedSet := schema.NewSet(schema.HashString, stringsAsInterfaces(expected.Strings()))
+		if actual.Equal(expectedSet) {
+			return nil
+		}
+
+		log.Printf("[INFO] ACL(s) drift detected for %s", diff.Id())
+		return diff.SetNew("acl_bindings", expectedSet)
+	}
+}
+
+// aclBindingsSchema is the computed attribute holding the bindings present in the cluster for a bundle resource.
+func aclBindingsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeSet,
+		Computed:    true,
+		Elem:        &schema.Schema{Type: schema.TypeString},
+		Description: "The ACL bindings present in the cluster on the resources and operations managed by this resource, including extra ones it does not expect",
+	}
+}
+
+// literalTopicOperations collects, per topic name, the operations allowed to the principal by literal topic bindings.
+func literalTopicOperations(foundAcls []sarama.ResourceAcls, principal string) (map[string]map[sarama.AclOperation]bool, []string) {
+	operations := make(map[string]map[sarama.AclOperation]bool)
+	topics := make([]string, 0)
+	for _, entity := range foundAcls {
+		if entity.ResourceType != sarama.AclResourceTopic || entity.ResourcePatternType != sarama.AclPatternLiteral {
 			continue
 		}
-		if len(aclEntity.Acls) < 1 {
-			break
+		for _, acl := range entity.Acls {
+			if acl.Principal != principal || acl.PermissionType != sarama.AclPermissionAllow {
+				continue
+			}
+			if _, ok := operations[entity.ResourceName]; !ok {
+				operations[entity.ResourceName] = make(map[sarama.AclOperation]bool)
+				topics = append(topics, entity.ResourceName)
+			}
+			operations[entity.ResourceName][acl.Operation] = true
+		}
+	}
+	sort.Strings(topics)
+	return operations, topics
+}
+
+// importAclRestrictions rebuilds the hosts and deny attributes of an imported bundle resource out of the bindings
+// of its principal, only looking at the ones on the resources and operations the imported bundle manages so the
+// restrictions of other resources of the same principal are not taken over. The hosts are the distinct hosts of
+// the managed ALLOW bindings, left empty when every binding is granted from any host. The topic DENY bindings on
+// an operation the bundle grants on a covering topic or prefix are kept, the ones sharing the same operations
+// grouped into one rule.
+func importAclRestrictions(d *schema.ResourceData, foundAcls []sarama.ResourceAcls, principal string,
+	fnConvert client.Convert, fnBuilder client.AclBuilder) error {
+
+	d.Set("hosts", []string{})
+	d.Set("deny", []interface{}{})
+	expected, err := fnBuilder(fnConvert(d))
+	if err != nil {
+		return err
+	}
+	managed := managedOperations(expected)
+
+	hosts := make([]string, 0)
+	seenHosts := make(map[string]bool)
+	deniedOperations := make(map[sarama.Resource]map[string]bool)
+	deniedResources := make([]sarama.Resource, 0)
+
+	for _, entity := range foundAcls {
+		for _, acl := range entity.Acls {
+			if acl.Principal != principal {
+				continue
+			}
+			switch acl.PermissionType {
+			case sarama.AclPermissionAllow:
+				if !managed[managedOperation{entity.Resource, acl.Operation}] {
+					continue
+				}
+				if !seenHosts[acl.Host] {
+					seenHosts[acl.Host] = true
+					hosts = append(hosts, acl.Host)
+				}
+			case sarama.AclPermissionDeny:
+				if entity.ResourceType != sarama.AclResourceTopic || !isTopicGranted(managed, entity.Resource, acl.Operation) {
+					continue
+				}
+				if _, ok := deniedOperations[entity.Resource]; !ok {
+					deniedOperations[entity.Resource] = make(map[string]bool)
+					deniedResources = append(deniedResources, entity.Resource)
+				}
+				deniedOperations[entity.Resource][acl.Operation.String()] = true
+			}
+		}
+	}
+
+	if len(hosts) == 1 && hosts[0] == "*" {
+		hosts = hosts[:0]
+	}
+	sort.Strings(hosts)
+
+	type denyRule struct {
+		operations    []string
+		topics        []string
+		topicPrefixes []string
+	}
+	rules := make(map[string]*denyRule)
+	ruleKeys := make([]string, 0)
+	for _, resource := range deniedResources {
+		operations := make([]string, 0, len(deniedOperations[resource]))
+		for operation := range deniedOperations[resource] {
+			operations = append(operations, operation)
 		}
-		log.Printf("[INFO] ACL(s) found resource %s, acls.Count = %d", aclEntity.ResourceName, len(aclEntity.Acls))
-		parser(d, aclInterface, aclEntity)
+		sort.Strings(operations)
+
+		key := strings.Join(operations, ",")
+		rule, ok := rules[key]
+		if !ok {
+			rule = &denyRule{operations: operations, topics: make([]string, 0), topicPrefixes: make([]string, 0)}
+			rules[key] = rule
+			ruleKeys = append(ruleKeys, key)
+		}
+		if resource.ResourcePatternType == sarama.AclPatternPrefixed {
+			rule.topicPrefixes = append(rule.topicPrefixes, resource.ResourceName)
+		} else {
+			rule.topics = append(rule.topics, resource.ResourceName)
+		}
+	}
+	sort.Strings(ruleKeys)
+
+	deny := make([]interface{}, len(ruleKeys))
+	for i, key := range ruleKeys {
+		rule := rules[key]
+		sort.Strings(rule.topics)
+		sort.Strings(rule.topicPrefixes)
+		deny[i] = map[string]interface{}{
+			"operations":     rule.operations,
+			"topics":         rule.topics,
+			"topic_prefixes": rule.topicPrefixes,
+		}
+	}
+
+	if err := d.Set("hosts", hosts); err != nil {
+		return err
+	}
+	return d.Set("deny", deny)
+}
+
+// isTopicGranted is true when a managed binding grants the operation on the topic, or on all the topics a
+// prefix matches, by a literal binding on the same name or a prefixed one on a prefix of its name.
+func isTopicGranted(managed map[managedOperation]bool, topic sarama.Resource, operation sarama.AclOperation) bool {
+	for granted := range managed {
+		if granted.operation != operation || granted.resource.ResourceType != sarama.AclResourceTopic {
+			continue
+		}
+		switch granted.resource.ResourcePatternType {
+		case sarama.AclPatternLiteral:
+			if topic.ResourcePatternType == sarama.AclPatternLiteral && topic.ResourceName == granted.resource.ResourceName {
+				return true
+			}
+		case sarama.AclPatternPrefixed:
+			if strings.HasPrefix(topic.ResourceName, granted.resource.ResourceName) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// funcVerifyImportedAcls fails the import if none of the bindings expected for the imported resource exist.
+func funcVerifyImportedAcls(c *client.KafkaCluster, d *schema.ResourceData, fnConvert client.Convert, fnBuilder client.AclBuilder) error {
+	expected, err := fnBuilder(fnConvert(d))
+	if err != nil {
+		return err
+	}
+
+	found, err := c.FindAcls(expected)
+	if err != nil {
+		return err
+	}
+
+	if len(found.Resources) == 0 {
+		return fmt.Errorf("no ACL(s) found matching %s", d.Id())
+	}
+	return nil
+}
+
+// hostsSchema is the optional list of hosts every binding of a bundle resource is restricted to.
+func hostsSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:     schema.TypeList,
+		Optional: true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+		Description: "The hosts the principal is allowed to connect from, one binding is created per host. Defaults to all hosts",
+	}
+}
+
+// denySchema is the optional collection of explicit DENY rules of a bundle resource.
+func denySchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Optional:    true,
+		Description: "Operations explicitly denied to the principal on a set of topics, taking precedence over the granted ones",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"operations": {
+					Type:     schema.TypeList,
+					Required: true,
+					MinItems: 1,
+					Elem: &schema.Schema{
+						Type:         schema.TypeString,
+						ValidateFunc: validation.StringInSlice(aclOperationNames, true),
+					},
+					Description: "The denied operations",
+				},
+				"topics": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Description: "The topics the operations are denied on",
+				},
+				"topic_prefixes": {
+					Type:     schema.TypeList,
+					Optional: true,
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+					Description: "The topic prefixes the operations are denied on",
+				},
+			},
+		},
+	}
+}
+
+func stringsAsInterfaces(values []string) []interface{} {
+	result := make([]interface{}, len(values))
+	for i, v := range values {
+		result[i] = v
 	}
+	return result
 }